@@ -0,0 +1,834 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cachesdev/souuup/internal/structtag"
+)
+
+// kind classifies the type of a field for code generation.
+type kind int
+
+const (
+	kindOther kind = iota
+	kindString
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindStruct
+	kindPointer
+	kindSlice
+	kindMap
+)
+
+// basicKinds maps the predeclared types to their kind.
+var basicKinds = map[string]kind{
+	"string":  kindString,
+	"bool":    kindBool,
+	"int":     kindInt,
+	"int8":    kindInt,
+	"int16":   kindInt,
+	"int32":   kindInt,
+	"int64":   kindInt,
+	"rune":    kindInt,
+	"uint":    kindUint,
+	"uint8":   kindUint,
+	"uint16":  kindUint,
+	"uint32":  kindUint,
+	"uint64":  kindUint,
+	"uintptr": kindUint,
+	"byte":    kindUint,
+	"float32": kindFloat,
+	"float64": kindFloat,
+}
+
+// basicBits maps the predeclared numeric types to their size in bits.
+var basicBits = map[string]int{
+	"int":     strconv.IntSize,
+	"int8":    8,
+	"int16":   16,
+	"int32":   32,
+	"int64":   64,
+	"rune":    32,
+	"uint":    strconv.IntSize,
+	"uint8":   8,
+	"uint16":  16,
+	"uint32":  32,
+	"uint64":  64,
+	"uintptr": strconv.IntSize,
+	"byte":    8,
+	"float32": 32,
+	"float64": 64,
+}
+
+// fieldType is the resolved type of a field.
+type fieldType struct {
+	kind kind
+
+	// name is the name of a named or predeclared type, used for type arguments and conversions
+	name string
+
+	// bits is the size in bits of numeric types
+	bits int
+
+	// elem is the element type of pointers, slices, arrays and maps
+	elem ast.Expr
+
+	// array reports whether a type of kindSlice is an array
+	array bool
+
+	// key is the key type of maps
+	key ast.Expr
+}
+
+// generator generates the schemas of the struct types of a package.
+type generator struct {
+	pkg     string
+	types   map[string]*ast.TypeSpec
+	imports map[string]bool
+	buf     bytes.Buffer
+
+	// pkgImports maps the names of the packages imported by the parsed files to their paths
+	pkgImports map[string]string
+}
+
+// Generate parses the Go files of the package in dir, ignoring test files, generated files and
+// the output file, and returns the formatted source of the schemas of the given types. If no
+// types are given, schemas are generated for every struct type with souuup tags. Schemas are
+// also generated for the struct types contained in the fields of the requested types.
+func Generate(dir string, typeNames []string, output string) ([]byte, error) {
+	g, err := parsePackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(typeNames) == 0 {
+		typeNames = g.taggedTypes()
+	}
+	if len(typeNames) == 0 {
+		return nil, errors.New("no struct types with souuup tags found")
+	}
+
+	names, err := g.closure(typeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, name := range names {
+		g.buf.Reset()
+		if err := g.generateType(name); err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by souuupgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", g.pkg)
+	g.writeImports(&src)
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// writeImports writes the import declaration of the generated file, with the standard library
+// packages grouped before the others.
+func (g *generator) writeImports(w *bytes.Buffer) {
+	var std, other []string
+	for path := range g.imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	w.WriteString("import (\n")
+	for _, path := range std {
+		fmt.Fprintf(w, "%q\n", path)
+	}
+	if len(std) > 0 {
+		w.WriteString("\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(w, "%q\n", path)
+	}
+	w.WriteString(")\n")
+}
+
+// parsePackage parses the type declarations of the package in dir.
+func parsePackage(dir, output string) (*generator, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		types:      make(map[string]*ast.TypeSpec),
+		imports:    map[string]bool{"github.com/cachesdev/souuup/u": true},
+		pkgImports: make(map[string]string),
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if ast.IsGenerated(file) {
+			continue
+		}
+
+		if g.pkg != "" && g.pkg != file.Name.Name {
+			return nil, fmt.Errorf("multiple packages in %s: %s and %s", dir, g.pkg, file.Name.Name)
+		}
+		g.pkg = file.Name.Name
+
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			g.pkgImports[name] = importPath
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.TypeParams == nil {
+					g.types[ts.Name.Name] = ts
+				}
+			}
+		}
+	}
+
+	if g.pkg == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return g, nil
+}
+
+// taggedTypes returns the struct types with at least one souuup tag, sorted by name.
+func (g *generator) taggedTypes() []string {
+	var names []string
+	for name, ts := range g.types {
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			if _, ok := tagValue(field, structtag.Key); ok {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// closure returns the given struct types, and the struct types contained in their fields, sorted by name.
+func (g *generator) closure(typeNames []string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if seen[name] {
+			return nil
+		}
+
+		st, ok := g.structType(name)
+		if !ok {
+			return fmt.Errorf("%s is not a struct type of package %s", name, g.pkg)
+		}
+		seen[name] = true
+
+		for _, field := range st.Fields.List {
+			if dep, ok := g.containedStruct(field.Type); ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, name := range typeNames {
+		if err := visit(strings.TrimSpace(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// structType returns the struct type declared with the given name.
+func (g *generator) structType(name string) (*ast.StructType, bool) {
+	ts, ok := g.types[name]
+	if !ok {
+		return nil, false
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	return st, ok
+}
+
+// containedStruct returns the name of the struct type of the package contained in a field type,
+// directly or as the element of pointers, slices, arrays and maps.
+func (g *generator) containedStruct(expr ast.Expr) (string, bool) {
+	ft := g.resolve(expr)
+	switch ft.kind {
+	case kindStruct:
+		return ft.name, true
+	case kindPointer, kindSlice, kindMap:
+		return g.containedStruct(ft.elem)
+	case kindOther, kindString, kindInt, kindUint, kindFloat, kindBool:
+	}
+	return "", false
+}
+
+// resolve resolves a type expression, following the named types of the package to their underlying type.
+func (g *generator) resolve(expr ast.Expr) fieldType {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if k, ok := basicKinds[expr.Name]; ok {
+			if _, shadowed := g.types[expr.Name]; !shadowed {
+				return fieldType{kind: k, name: expr.Name, bits: basicBits[expr.Name]}
+			}
+		}
+
+		ts, ok := g.types[expr.Name]
+		if !ok {
+			return fieldType{kind: kindOther, name: expr.Name}
+		}
+		if st, ok := ts.Type.(*ast.StructType); ok {
+			if !hasExportedFields(st) {
+				// Like time.Time, structs without exported fields are validated as values
+				return fieldType{kind: kindOther, name: expr.Name}
+			}
+			return fieldType{kind: kindStruct, name: expr.Name}
+		}
+
+		ft := g.resolve(ts.Type)
+		if ft.kind != kindPointer {
+			ft.name = expr.Name
+		}
+		return ft
+
+	case *ast.ParenExpr:
+		return g.resolve(expr.X)
+	case *ast.StarExpr:
+		return fieldType{kind: kindPointer, elem: expr.X}
+	case *ast.ArrayType:
+		return fieldType{kind: kindSlice, elem: expr.Elt, array: expr.Len != nil}
+	case *ast.MapType:
+		return fieldType{kind: kindMap, key: expr.Key, elem: expr.Value}
+	}
+	return fieldType{kind: kindOther}
+}
+
+// hasExportedFields reports whether a struct type has exported fields, including embedded ones.
+func hasExportedFields(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if slices.ContainsFunc(fieldNames(field), (*ast.Ident).IsExported) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateType writes the Schema and Validate methods of a struct type.
+func (g *generator) generateType(name string) error {
+	st, _ := g.structType(name)
+
+	var body bytes.Buffer
+	if err := g.fields(&body, st); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "\n// Schema returns the validation schema of %s.\n", name)
+	fmt.Fprintf(&g.buf, "func (v %s) Schema() u.Schema {\n", name)
+	g.buf.WriteString("s := u.Schema{}\n")
+	g.buf.Write(body.Bytes())
+	g.buf.WriteString("return s\n}\n")
+
+	fmt.Fprintf(&g.buf, "\n// Validate validates %s against its schema.\n", name)
+	fmt.Fprintf(&g.buf, "func (v %s) Validate() error {\n", name)
+	g.buf.WriteString("return u.NewSouuup(v.Schema()).Validate()\n}\n")
+	return nil
+}
+
+// fields writes the statements adding the fields of a struct type to the schema s.
+func (g *generator) fields(w *bytes.Buffer, st *ast.StructType) error {
+	for _, field := range st.Fields.List {
+		tag, _ := tagValue(field, structtag.Key)
+		rules, skip, err := structtag.Parse(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", exprString(field.Type), err)
+		}
+		jsonTag, hasJSON := tagValue(field, "json")
+
+		if skip {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if promoted, name := g.promoted(field, rules, hasJSON); promoted {
+				g.embedded(w, field, name)
+				continue
+			}
+		}
+
+		for _, ident := range fieldNames(field) {
+			name, skipJSON := structtag.FieldName(ident.Name, jsonTag)
+			if skipJSON || !ident.IsExported() {
+				continue
+			}
+
+			if err := g.field(w, strconv.Quote(name), "v."+ident.Name, field.Type, rules); err != nil {
+				return fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// promoted reports whether the fields of an embedded field are promoted, as with encoding/json,
+// which is the case for embedded structs of the package without rules or a json name. It also
+// returns the name of the embedded type.
+func (g *generator) promoted(field *ast.Field, rules []structtag.Rule, hasJSON bool) (bool, string) {
+	ident, _ := embeddedType(field)
+	if ident == nil || len(rules) > 0 || hasJSON {
+		return false, ""
+	}
+	return g.resolve(ident).kind == kindStruct, ident.Name
+}
+
+// embedded writes the statements promoting the fields of an embedded struct.
+func (g *generator) embedded(w *bytes.Buffer, field *ast.Field, name string) {
+	g.imports["maps"] = true
+	if _, pointer := embeddedType(field); pointer {
+		fmt.Fprintf(w, "if v.%s != nil {\nmaps.Copy(s, v.%s.Schema())\n}\n", name, name)
+	} else {
+		fmt.Fprintf(w, "maps.Copy(s, v.%s.Schema())\n", name)
+	}
+}
+
+// embeddedType returns the type name of an embedded field, and whether it is embedded as a pointer.
+// It returns nil for embedded types of other packages.
+func embeddedType(field *ast.Field) (*ast.Ident, bool) {
+	typ, pointer := field.Type, false
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ, pointer = star.X, true
+	}
+	ident, _ := typ.(*ast.Ident)
+	return ident, pointer
+}
+
+// fieldNames returns the names of a field, which is the type name for embedded fields.
+func fieldNames(field *ast.Field) []*ast.Ident {
+	if len(field.Names) > 0 {
+		return field.Names
+	}
+	if ident, _ := embeddedType(field); ident != nil {
+		return []*ast.Ident{ident}
+	}
+	if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+		return []*ast.Ident{sel.Sel}
+	}
+	if star, ok := field.Type.(*ast.StarExpr); ok {
+		if sel, ok := star.X.(*ast.SelectorExpr); ok {
+			return []*ast.Ident{sel.Sel}
+		}
+	}
+	return nil
+}
+
+// field writes the statements adding a field to the schema s under tag, where access is the
+// expression of the field value.
+func (g *generator) field(w *bytes.Buffer, tag, access string, typ ast.Expr, rules []structtag.Rule) error {
+	ft := g.resolve(typ)
+	if ft.kind != kindPointer {
+		if err := structtag.Check(rules, ft.tagType(exprString(typ))); err != nil {
+			return err
+		}
+	}
+
+	switch ft.kind {
+	case kindPointer:
+		return g.pointerField(w, tag, access, ft, rules)
+
+	case kindString:
+		value := access
+		if ft.name != "string" {
+			value = "string(" + access + ")"
+		}
+		return g.scalarField(w, tag, value, rules, stringRule)
+
+	case kindInt, kindUint, kindFloat:
+		return g.scalarField(w, tag, access, rules, func(rule structtag.Rule) (string, error) {
+			return numericRule(rule, ft)
+		})
+
+	case kindStruct:
+		fmt.Fprintf(w, "s[%s] = %s.Schema()\n", tag, access)
+		return nil
+
+	case kindSlice, kindMap:
+		return g.collectionField(w, tag, access, ft, rules)
+
+	case kindBool, kindOther:
+		// Only required applies, which scalarField writes without converting it
+		return g.scalarField(w, tag, access, rules, nil)
+	}
+	return nil
+}
+
+// tagType describes the type for checking tags, with the kind of its values which determines the
+// rules they support, and name describing them in errors.
+func (ft fieldType) tagType(name string) structtag.Type {
+	typ := structtag.Type{Kind: structtag.KindOther, Bits: ft.bits, Name: name}
+	switch ft.kind {
+	case kindString:
+		typ.Kind = structtag.KindString
+	case kindInt:
+		typ.Kind = structtag.KindInt
+	case kindUint:
+		typ.Kind = structtag.KindUint
+	case kindFloat:
+		typ.Kind = structtag.KindFloat
+	case kindSlice, kindMap:
+		typ.Kind = structtag.KindCollection
+	case kindStruct:
+		typ.Kind = structtag.KindStruct
+	case kindOther, kindBool, kindPointer:
+	}
+	return typ
+}
+
+// pointerField writes the statements of a pointer field, which is validated when it is not nil.
+func (g *generator) pointerField(w *bytes.Buffer, tag, access string, ft fieldType, rules []structtag.Rule) error {
+	required := slices.ContainsFunc(rules, isRequired)
+	rules = slices.DeleteFunc(slices.Clone(rules), isRequired)
+
+	deref := "*" + access
+	if g.resolve(ft.elem).kind == kindStruct {
+		deref = access
+	}
+
+	var inner bytes.Buffer
+	if err := g.field(&inner, tag, deref, ft.elem, rules); err != nil {
+		return err
+	}
+
+	if inner.Len() == 0 && !required {
+		return nil
+	}
+
+	fmt.Fprintf(w, "if %s != nil {\n", access)
+	w.Write(inner.Bytes())
+	if required {
+		g.imports["github.com/cachesdev/souuup/r"] = true
+		fmt.Fprintf(w, "} else {\ns[%s] = u.Field(false, r.NotZero[bool])\n", tag)
+	}
+	w.WriteString("}\n")
+	return nil
+}
+
+// scalarField writes the statement of a scalar field, converting its rules with convert.
+func (g *generator) scalarField(w *bytes.Buffer, tag, value string, rules []structtag.Rule, convert func(structtag.Rule) (string, error)) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	args := []string{value}
+	for _, rule := range rules {
+		if isRequired(rule) {
+			args = append(args, "r.NotZero")
+			continue
+		}
+
+		code, err := convert(rule)
+		if err != nil {
+			return err
+		}
+		args = append(args, code)
+	}
+
+	g.imports["github.com/cachesdev/souuup/r"] = true
+	fmt.Fprintf(w, "s[%s] = u.Field(%s)\n", tag, strings.Join(args, ", "))
+	return nil
+}
+
+// collectionField writes the statements of a slice, array or map field, validating its length
+// and any struct elements.
+func (g *generator) collectionField(w *bytes.Buffer, tag, access string, ft fieldType, rules []structtag.Rule) error {
+	var field string
+	if len(rules) > 0 {
+		args := []string{g.collectionValue(access, ft)}
+		for _, rule := range rules {
+			code, err := g.lengthRule(rule, ft)
+			if err != nil {
+				return err
+			}
+			args = append(args, code)
+		}
+		g.imports["github.com/cachesdev/souuup/r"] = true
+		field = "u.Field(" + strings.Join(args, ", ") + ")"
+	}
+
+	elem := g.resolve(ft.elem)
+	pointer := elem.kind == kindPointer
+	if pointer {
+		elem = g.resolve(elem.elem)
+	}
+
+	if elem.kind != kindStruct {
+		if field != "" {
+			fmt.Fprintf(w, "s[%s] = %s\n", tag, field)
+		}
+		return nil
+	}
+
+	w.WriteString("{\n")
+	if ft.kind == kindMap {
+		key := "key"
+		if g.resolve(ft.key).kind != kindString || g.resolve(ft.key).name != "string" {
+			g.imports["fmt"] = true
+			key = "fmt.Sprint(key)"
+		}
+		w.WriteString("elems := u.Schema{}\n")
+		fmt.Fprintf(w, "for key, elem := range %s {\n", access)
+		if pointer {
+			w.WriteString("if elem != nil {\n")
+		}
+		fmt.Fprintf(w, "elems[%s] = elem.Schema()\n", key)
+	} else {
+		g.imports["strconv"] = true
+		w.WriteString("elems := u.Ordered()\n")
+		fmt.Fprintf(w, "for i, elem := range %s {\n", access)
+		if pointer {
+			w.WriteString("if elem != nil {\n")
+		}
+		w.WriteString("elems = append(elems, u.Entry(strconv.Itoa(i), elem.Schema()))\n")
+	}
+	if pointer {
+		w.WriteString("}\n")
+	}
+	w.WriteString("}\n")
+
+	if field != "" {
+		fmt.Fprintf(w, "s[%s] = u.WithNested(%s, elems)\n", tag, field)
+	} else {
+		fmt.Fprintf(w, "s[%s] = elems\n", tag)
+	}
+	w.WriteString("}\n")
+	return nil
+}
+
+// collectionValue returns the expression of the value of a collection validated by length rules,
+// which is a slice or map of an unnamed type, as the rules of the r package are instantiated with
+// the element type.
+func (g *generator) collectionValue(access string, ft fieldType) string {
+	switch {
+	case ft.array:
+		if strings.HasPrefix(access, "*") {
+			access = "(" + access + ")"
+		}
+		return access + "[:]"
+	case ft.name == "":
+		return access
+	case ft.kind == kindMap:
+		return fmt.Sprintf("map[%s]%s(%s)", g.typeString(ft.key), g.typeString(ft.elem), access)
+	default:
+		return fmt.Sprintf("[]%s(%s)", g.typeString(ft.elem), access)
+	}
+}
+
+// stringRule returns the code of a string rule.
+func stringRule(rule structtag.Rule) (string, error) {
+	switch rule.Name {
+	case structtag.Min, structtag.Max, structtag.Len:
+		n, err := rule.Length()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("r.%sS(%d)", map[string]string{
+			structtag.Min: "Min",
+			structtag.Max: "Max",
+			structtag.Len: "Len",
+		}[rule.Name], n), nil
+	case structtag.In, structtag.NotIn:
+		set := make([]string, 0, len(rule.List()))
+		for _, member := range rule.List() {
+			set = append(set, strconv.Quote(member))
+		}
+		fn := "InS"
+		if rule.Name == structtag.NotIn {
+			fn = "NotInS"
+		}
+		return fmt.Sprintf("r.%s([]string{%s})", fn, strings.Join(set, ", ")), nil
+	case structtag.Contains:
+		return fmt.Sprintf("r.ContainsS(%s)", strconv.Quote(rule.Arg)), nil
+	}
+	return "", fmt.Errorf("rule %q is not a string rule", rule.Name)
+}
+
+// numericRules maps the numeric tag rules to the r rules.
+var numericRules = map[string]string{
+	structtag.Min: "MinN",
+	structtag.Gte: "MinN",
+	structtag.Max: "MaxN",
+	structtag.Lte: "MaxN",
+	structtag.Gt:  "Gt",
+	structtag.Lt:  "Lt",
+	structtag.Neq: "NeqN",
+}
+
+// numericRule returns the code of a numeric rule, instantiated with the type of the field. The
+// argument is parsed for the size of the type and written as parsed, e.g. "+1e3" as 1000.
+func numericRule(rule structtag.Rule, ft fieldType) (string, error) {
+	fn, ok := numericRules[rule.Name]
+	if !ok {
+		return "", fmt.Errorf("rule %q is not a numeric rule", rule.Name)
+	}
+
+	var arg string
+	switch ft.kind {
+	case kindInt:
+		n, err := rule.Int(ft.bits)
+		if err != nil {
+			return "", err
+		}
+		arg = strconv.FormatInt(n, 10)
+	case kindUint:
+		n, err := rule.Uint(ft.bits)
+		if err != nil {
+			return "", err
+		}
+		arg = strconv.FormatUint(n, 10)
+	case kindFloat:
+		f, err := rule.Float(ft.bits)
+		if err != nil {
+			return "", err
+		}
+		arg = strconv.FormatFloat(f, 'g', -1, ft.bits)
+	case kindOther, kindString, kindBool, kindStruct, kindPointer, kindSlice, kindMap:
+		return "", fmt.Errorf("rule %q is not a numeric rule", rule.Name)
+	}
+
+	return fmt.Sprintf("r.%s[%s](%s)", fn, ft.name, arg), nil
+}
+
+// lengthRule returns the code of a length rule of a collection, instantiated with the element
+// type of slices and arrays, or the key and value types of maps. Maps have no exact length rule,
+// so len is written as both a minimum and a maximum number of keys.
+func (g *generator) lengthRule(rule structtag.Rule, ft fieldType) (string, error) {
+	n := 1
+	if !isRequired(rule) {
+		var err error
+		if n, err = rule.Length(); err != nil {
+			return "", err
+		}
+	}
+
+	if ft.kind == kindMap {
+		typeArgs := g.typeString(ft.key) + ", " + g.typeString(ft.elem)
+		switch rule.Name {
+		case structtag.Required, structtag.Min:
+			return fmt.Sprintf("r.MinKeys[%s](%d)", typeArgs, n), nil
+		case structtag.Max:
+			return fmt.Sprintf("r.MaxKeys[%s](%d)", typeArgs, n), nil
+		case structtag.Len:
+			return fmt.Sprintf("r.MinKeys[%s](%d), r.MaxKeys[%s](%d)", typeArgs, n, typeArgs, n), nil
+		}
+		return "", fmt.Errorf("rule %q is not a length rule", rule.Name)
+	}
+
+	fn, ok := map[string]string{
+		structtag.Required: "MinLen",
+		structtag.Min:      "MinLen",
+		structtag.Max:      "MaxLen",
+		structtag.Len:      "ExactLen",
+	}[rule.Name]
+	if !ok {
+		return "", fmt.Errorf("rule %q is not a length rule", rule.Name)
+	}
+	return fmt.Sprintf("r.%s[%s](%d)", fn, g.typeString(ft.elem), n), nil
+}
+
+// isRequired reports whether rule is the required rule.
+func isRequired(rule structtag.Rule) bool {
+	return rule.Name == structtag.Required
+}
+
+// tagValue returns the value of a key in the tag of a field.
+func tagValue(field *ast.Field, key string) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup(key)
+}
+
+// typeString returns the source of a type expression written in the generated file, importing
+// the packages it refers to.
+func (g *generator) typeString(expr ast.Expr) string {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if importPath, ok := g.pkgImports[pkg.Name]; ok {
+					g.imports[importPath] = true
+				}
+			}
+		}
+		return true
+	})
+	return exprString(expr)
+}
+
+// exprString returns the source of a type expression.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+	return buf.String()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range dirs {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			// Arrange
+			golden := filepath.Join(dir, defaultOutput)
+
+			// Act
+			src, err := Generate(dir, nil, defaultOutput)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Assert
+			if *update {
+				if err := os.WriteFile(golden, src, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(src, expected) {
+				t.Errorf("generated code does not match %s, run go test with -update\n%s", golden, src)
+			}
+		})
+	}
+}
+
+// TestGeneratedCode runs the tests of the golden packages, which compile the generated code
+// against the current u and r packages and compare it with the schemas of the tags package.
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of the golden packages in short mode")
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	dirs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"test"}
+	for _, dir := range dirs {
+		args = append(args, "./"+filepath.ToSlash(dir))
+	}
+
+	cmd := exec.CommandContext(t.Context(), goBin, args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go test of the generated code failed: %v\n%s", err, out)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		types []string
+	}{
+		{
+			name: "no tagged types",
+			src:  "package p\n\ntype T struct{ Name string }\n",
+		},
+		{
+			name:  "unknown type",
+			src:   "package p\n\ntype T struct{ Name string `souuup:\"required\"` }\n",
+			types: []string{"Missing"},
+		},
+		{
+			name: "unknown rule",
+			src:  "package p\n\ntype T struct{ Name string `souuup:\"email\"` }\n",
+		},
+		{
+			name: "rule not supported for the type",
+			src:  "package p\n\ntype T struct{ Admin bool `souuup:\"min=1\"` }\n",
+		},
+		{
+			name: "invalid number",
+			src:  "package p\n\ntype T struct{ Age uint `souuup:\"min=-1\"` }\n",
+		},
+		{
+			name: "number overflowing the type",
+			src:  "package p\n\ntype T struct{ Age int8 `souuup:\"min=300\"` }\n",
+		},
+		{
+			name: "non-finite number",
+			src:  "package p\n\ntype T struct{ Price float64 `souuup:\"max=NaN\"` }\n",
+		},
+		{
+			name: "rules on struct values",
+			src:  "package p\n\ntype A struct{ Name string }\n\ntype T struct{ A A `souuup:\"required\"` }\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(tt.src), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			_, err := Generate(dir, tt.types, defaultOutput)
+
+			// Assert
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Command souuupgen generates reflection-free souuup schemas from struct tags.
+//
+// For every struct type in a package with souuup tags, and the struct types it contains,
+// souuupgen generates a Schema method returning a u.Schema built with u.Field and the rules
+// of the r package, and a Validate method validating the value against it. The tags are
+// the same as those read by the tags package:
+//
+//	//go:generate go run github.com/cachesdev/souuup/cmd/souuupgen -type UserRegistration
+//
+//	type UserRegistration struct {
+//		Username string `json:"username" souuup:"required,min=3,max=20"`
+//		Age      int    `json:"age" souuup:"min=18"`
+//	}
+//
+// Usage:
+//
+//	souuupgen [-type T1,T2] [-output file] [dir]
+//
+// The generated file is written to souuup_gen.go in the package directory, which defaults
+// to the current directory, unless -output is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultOutput is the name of the generated file when -output is not given.
+const defaultOutput = "souuup_gen.go"
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of type names; defaults to every tagged struct type")
+	output := flag.String("output", "", "output file name; defaults to <dir>/"+defaultOutput)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: souuupgen [-type T1,T2] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	outputFile := *output
+	if outputFile == "" {
+		outputFile = filepath.Join(dir, defaultOutput)
+	}
+
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	src, err := Generate(dir, types, filepath.Base(outputFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "souuupgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(outputFile, src, 0o644); err != nil { //nolint:gosec // generated source is not secret
+		fmt.Fprintf(os.Stderr, "souuupgen: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,75 @@
+package orders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cachesdev/souuup/tags"
+	"github.com/cachesdev/souuup/u"
+)
+
+// TestGeneratedMatchesTags checks that the generated schemas report the same errors as
+// the schemas built from the same tags through reflection.
+func TestGeneratedMatchesTags(t *testing.T) {
+	nickname := "a very long nickname"
+	valid := func() Order {
+		return Order{
+			timestamps: timestamps{CreatedBy: "admin", CreatedAt: time.Now()},
+			ID:         1,
+			Username:   "johndoe",
+			Size:       "small",
+			Code:       "A-1",
+			Address:    &Address{Street: "Main St", City: "London"},
+			Items:      []Item{{SKU: "A001", Quantity: 1, Price: 1}},
+			Labels:     map[string]string{"team": "core"},
+			Metadata:   map[string]int{"priority": 1},
+			Gift:       true,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(o *Order)
+	}{
+		{name: "valid", modify: func(*Order) {}},
+		{name: "scalars", modify: func(o *Order) {
+			o.ID, o.Username, o.Size, o.Code, o.Discount, o.Gift = 0, "jo", "huge", "test", 1, false
+		}},
+		{name: "pointers", modify: func(o *Order) {
+			o.Address, o.Nickname, o.Billing = nil, &nickname, &Address{City: "P"}
+		}},
+		{name: "collections", modify: func(o *Order) {
+			o.Items = nil
+			o.Extras = []*Item{nil, {SKU: "B2", Quantity: 11}}
+			o.Tags = []string{"a", "b", "c"}
+			o.Aliases = Aliases{"a", "b", "c"}
+			o.Dates = []time.Time{time.Now(), time.Now()}
+			o.Labels = nil
+			o.Metadata = map[string]int{"priority": 1, "weight": 2}
+			o.Addresses = map[string]Address{"work": {City: "Paris"}}
+		}},
+		{name: "promoted fields", modify: func(o *Order) {
+			o.timestamps = timestamps{}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			o := valid()
+			tt.modify(&o)
+
+			// Act
+			generated := o.Validate()
+			reflected := u.NewSouuup(tags.MustFromStruct(o)).Validate()
+
+			// Assert
+			if (generated == nil) != (reflected == nil) {
+				t.Fatalf("expected %v, got %v", reflected, generated)
+			}
+			if generated != nil && generated.Error() != reflected.Error() {
+				t.Errorf("expected %s, got %s", reflected.Error(), generated.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package orders is the input of the souuupgen golden tests.
+package orders
+
+import "time"
+
+type Size string
+
+type Quantity uint
+
+type Aliases []string
+
+type Address struct {
+	Street string `json:"street" souuup:"required"`
+	City   string `json:"city" souuup:"min=2"`
+}
+
+type Item struct {
+	SKU      string   `json:"sku" souuup:"len=4"`
+	Quantity Quantity `json:"qty" souuup:"gt=0,lte=10"`
+	Price    float64  `json:"price" souuup:"min=0.01"`
+}
+
+type timestamps struct {
+	CreatedBy string    `json:"createdBy" souuup:"required"`
+	CreatedAt time.Time `json:"createdAt" souuup:"required"`
+}
+
+type Order struct {
+	timestamps
+
+	ID        int64              `json:"id" souuup:"required"`
+	Username  string             `json:"username" souuup:"required,min=3,max=20"`
+	Size      Size               `json:"size" souuup:"in=small|medium|large"`
+	Code      string             `json:"code" souuup:"notin=test|demo,contains=-"`
+	Discount  float64            `json:"discount,omitempty" souuup:"min=0,lt=1"`
+	Nickname  *string            `json:"nickname" souuup:"max=10"`
+	Address   *Address           `json:"address" souuup:"required"`
+	Billing   *Address           `json:"billing"`
+	Items     []Item             `json:"items" souuup:"min=1"`
+	Extras    []*Item            `json:"extras"`
+	Tags      []string           `json:"tags" souuup:"max=2"`
+	Aliases   Aliases            `json:"aliases" souuup:"max=2"`
+	Codes     [2]string          `json:"codes" souuup:"len=2"`
+	Dates     []time.Time        `json:"dates" souuup:"max=1"`
+	Labels    map[string]string  `json:"labels" souuup:"required"`
+	Metadata  map[string]int     `json:"metadata" souuup:"len=1"`
+	Addresses map[string]Address `json:"addresses"`
+	Gift      bool               `json:"gift" souuup:"required"`
+	Notes     string             `json:"-" souuup:"required"`
+	Internal  string             `souuup:"-"`
+	secret    string
+}
@@ -154,12 +154,14 @@ func main() {
 		Status:      "processing",
 	}
 
+	paidByCard := order.PaymentInfo.Method == "credit_card" || order.PaymentInfo.Method == "debit_card"
+
 	// Create complex nested validation schema
 	orderSchema := u.Schema{
 		"orderID":    u.Field(order.OrderID, r.NotZero, r.MinS(5)),
 		"customerID": u.Field(order.CustomerID, r.NotZero),
 		"orderDate":  u.Field(order.OrderDate, PastDate),
-		"shipDate":   u.Field(*order.ShipDate, FutureDate),
+		"shipDate":   u.Optional(order.ShipDate, FutureDate),
 		"items": u.Field(order.Items,
 			r.MinLen[OrderItem](1),         // At least one item required
 			r.MaxLen[OrderItem](10),        // Maximum 10 items allowed
@@ -174,20 +176,22 @@ func main() {
 		},
 		"paymentInfo": u.Schema{
 			"method": u.Field(order.PaymentInfo.Method, ValidPaymentMethod),
-			"cardLastFour": u.Field(order.PaymentInfo.CardLastFour, r.NotZero, func(fs u.FieldState[string]) error {
+			// Card details are only validated when paying by card
+			"cardLastFour": u.Field(order.PaymentInfo.CardLastFour, u.When(paidByCard, r.NotZero, func(fs u.FieldState[string]) error {
 				if len(fs.Value) != 4 {
 					return fmt.Errorf("card last four must be exactly 4 digits")
 				}
 				return nil
-			}),
-			"expiration": u.Field(true, func(fs u.FieldState[bool]) error {
+			})),
+			"expiration": u.If(paidByCard, u.Field(true, func(fs u.FieldState[bool]) error {
 				if !ValidCardExpiration(order.PaymentInfo.ExpirationMonth, order.PaymentInfo.ExpirationYear) {
 					return fmt.Errorf("card expiration date is invalid")
 				}
 				return nil
-			}),
+			})),
 		},
-		"totalAmount": u.Field(order.TotalAmount, r.MinN(0.0)),
+		// Unusually large orders are reported as warnings, which do not fail validation
+		"totalAmount": u.Field(order.TotalAmount, r.MinN(0.0), u.Warn(r.MaxN(1000.0))),
 		"status": u.Field(order.Status, func(fs u.FieldState[string]) error {
 			validStatuses := map[string]bool{
 				"pending":    true,
@@ -208,11 +212,18 @@ func main() {
 	s := u.NewSouuup(orderSchema)
 
 	// Validate order
-	err := s.Validate()
+	res, err := s.Check()
 	if err != nil {
-		fmt.Printf("Order validation failed: %s\n", err)
+		fmt.Printf("Order validation was cancelled: %s\n", err)
 		return
 	}
+	if !res.Valid() {
+		fmt.Printf("Order validation failed: %s\n", res.Errors)
+		return
+	}
+	if res.Warnings != nil {
+		fmt.Printf("Order validated with warnings: %s\n", res.Warnings)
+	}
 
 	fmt.Println("✅ Order validated successfully!")
 
@@ -243,15 +254,18 @@ func main() {
 		"orderID":    u.Field(invalidOrder.OrderID, r.NotZero, r.MinS(5)),
 		"customerID": u.Field(invalidOrder.CustomerID, r.NotZero),
 		"orderDate":  u.Field(invalidOrder.OrderDate, PastDate),
-		"shipDate":   u.Field(*invalidOrder.ShipDate, FutureDate),
-		"items": u.Schema{
-			"count": u.Field(len(invalidOrder.Items), r.MinN(1)),
-			"item0": u.Schema{
-				"productID": u.Field(invalidOrder.Items[0].ProductID, r.NotZero),
-				"quantity":  u.Field(invalidOrder.Items[0].Quantity, r.MinN(1)),
-				"unitPrice": u.Field(invalidOrder.Items[0].UnitPrice, r.MinN(0.01)),
-			},
-		},
+		"shipDate":   u.Optional(invalidOrder.ShipDate, FutureDate),
+		// Every item is validated with its own schema, and failures are reported under its index
+		"items": u.WithNested(
+			u.Field(invalidOrder.Items, r.MinLen[OrderItem](1)),
+			u.Each(invalidOrder.Items, func(item OrderItem) u.Schema {
+				return u.Schema{
+					"productID": u.Field(item.ProductID, r.NotZero),
+					"quantity":  u.Field(item.Quantity, r.MinN(1)),
+					"unitPrice": u.Field(item.UnitPrice, r.MinN(10.0)),
+				}
+			}),
+		),
 		"paymentInfo": u.Schema{
 			"method": u.Field(invalidOrder.PaymentInfo.Method, ValidPaymentMethod),
 		},
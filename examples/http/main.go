@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/cachesdev/souuup/problem"
 	"github.com/cachesdev/souuup/r"
 	"github.com/cachesdev/souuup/u"
 )
@@ -30,13 +31,12 @@ func ValidEmail(fs u.FieldState[string]) error {
 	return nil
 }
 
-func PasswordMatchRule(reg UserRegistration) u.Rule[string] {
-	return func(fs u.FieldState[string]) error {
-		if fs.Value != reg.ConfirmPassword {
-			return fmt.Errorf("passwords do not match")
-		}
+func PasswordMatchRule(ss u.SchemaState[UserRegistration]) error {
+	// An invalid password is already reported on its field
+	if ss.Failed("password") || ss.Value.Password == ss.Value.ConfirmPassword {
 		return nil
 	}
+	return u.ErrorFor(fmt.Errorf("passwords do not match"), "confirmPassword")
 }
 
 func StrongPasswordRule(fs u.FieldState[string]) error {
@@ -75,32 +75,31 @@ func registerHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// Normalise the email before validating it
+	email := u.Field(reg.Email, u.Transform(strings.TrimSpace, strings.ToLower), r.NotZero, ValidEmail)
+
 	// Create validation schema
-	schema := u.Schema{
-		"username": u.Field(reg.Username, r.NotZero, r.MinS(3), r.MaxS(20)),
-		"email":    u.Field(reg.Email, r.NotZero, ValidEmail),
-		"password": u.Field(reg.Password, r.NotZero, StrongPasswordRule, PasswordMatchRule(reg)),
-		"age":      u.Field(reg.Age, r.MinN(18)),
-	}
+	schema := u.WithRules(u.Schema{
+		"username":        u.Field(reg.Username, r.NotZero, r.MinS(3), r.MaxS(20)),
+		"email":           email,
+		"password":        u.Field(reg.Password, r.NotZero, StrongPasswordRule),
+		"confirmPassword": u.Field(reg.ConfirmPassword),
+		"age":             u.Field(reg.Age, r.MinN(18)),
+	}, reg, PasswordMatchRule)
 
 	// Create validator
 	s := u.NewSouuup(schema)
 
 	// Validate
 	if err := s.Validate(); err != nil {
-		// Return validation errors as JSON
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(map[string]any{
-			"success": false,
-			"message": "Validation failed",
-			"errors":  err.Error(),
-		})
+		// Return validation errors as an application/problem+json document
+		_ = problem.Write(w, err, problem.WithInstance(req.URL.Path))
 		return
 	}
 
-	// If validation passes, process the registration
+	// If validation passes, process the registration with the normalised email
 	// (in a real app, this would save the user to a database)
+	reg.Email = email.Value()
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(map[string]any{
@@ -0,0 +1,248 @@
+// Package structtag parses the souuup struct tags shared by the tags package and souuupgen.
+//
+// A tag is a comma separated list of rules, each optionally followed by "=" and an argument:
+//
+//	Username string `json:"username" souuup:"required,min=3,max=20"`
+//	Size     string `json:"size" souuup:"in=small|medium|large"`
+package structtag
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Key is the struct tag key read by souuup.
+const Key = "souuup"
+
+// Rule names supported in tags.
+const (
+	Required = "required"
+	Min      = "min"
+	Max      = "max"
+	Len      = "len"
+	Gt       = "gt"
+	Lt       = "lt"
+	Gte      = "gte"
+	Lte      = "lte"
+	Neq      = "neq"
+	In       = "in"
+	NotIn    = "notin"
+	Contains = "contains"
+)
+
+// ErrUnknownRule is returned when a tag contains a rule name that is not supported.
+var ErrUnknownRule = errors.New("unknown rule")
+
+// takesArg reports, for every supported rule, whether it requires an argument.
+var takesArg = map[string]bool{
+	Required: false,
+	Min:      true,
+	Max:      true,
+	Len:      true,
+	Gt:       true,
+	Lt:       true,
+	Gte:      true,
+	Lte:      true,
+	Neq:      true,
+	In:       true,
+	NotIn:    true,
+	Contains: true,
+}
+
+// Kind is the kind of value the rules of a tag are applied to. Pointers have the kind of their
+// element, and also support Required.
+type Kind int
+
+const (
+	// KindOther is the kind of values that only support Required, such as booleans and times.
+	KindOther Kind = iota
+
+	// KindString is the kind of strings.
+	KindString
+
+	// KindInt is the kind of signed integers.
+	KindInt
+
+	// KindUint is the kind of unsigned integers.
+	KindUint
+
+	// KindFloat is the kind of floats.
+	KindFloat
+
+	// KindCollection is the kind of slices, arrays and maps, whose rules apply to their length.
+	KindCollection
+
+	// KindStruct is the kind of structs validated as nested schemas, which support no rules.
+	KindStruct
+)
+
+// numberRules lists the rules that apply to numbers.
+var numberRules = []string{Required, Min, Max, Gt, Lt, Gte, Lte, Neq}
+
+// supported lists the rules that apply to each kind of value.
+var supported = map[Kind][]string{
+	KindOther:      {Required},
+	KindString:     {Required, Min, Max, Len, In, NotIn, Contains},
+	KindInt:        numberRules,
+	KindUint:       numberRules,
+	KindFloat:      numberRules,
+	KindCollection: {Required, Min, Max, Len},
+	KindStruct:     nil,
+}
+
+// Type describes the type of the values the rules of a tag are applied to.
+type Type struct {
+	// Kind is the kind of the values.
+	Kind Kind
+
+	// Bits is the size in bits of numbers, which bounds the arguments of their rules.
+	Bits int
+
+	// Name describes the values in errors, e.g. "string" or the name of their type.
+	Name string
+}
+
+// Rule is a single rule of a tag.
+type Rule struct {
+	Name string
+	Arg  string
+}
+
+// List returns the argument of a set rule, such as "in=a|b|c", as its members.
+func (r Rule) List() []string {
+	return strings.Split(r.Arg, "|")
+}
+
+// String returns the rule as it is written in a tag.
+func (r Rule) String() string {
+	if r.Arg == "" {
+		return r.Name
+	}
+	return r.Name + "=" + r.Arg
+}
+
+// Parse parses the value of a souuup struct tag into its rules, in the order they are written.
+// The second return value is true if the field is skipped with the tag "-".
+func Parse(tag string) ([]Rule, bool, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "-" {
+		return nil, true, nil
+	}
+	if tag == "" {
+		return nil, false, nil
+	}
+
+	var rules []Rule
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+
+		needsArg, known := takesArg[name]
+		switch {
+		case !known:
+			return nil, false, fmt.Errorf("%w %q", ErrUnknownRule, name)
+		case needsArg && (!hasArg || arg == ""):
+			return nil, false, fmt.Errorf("rule %q needs an argument", name)
+		case !needsArg && hasArg:
+			return nil, false, fmt.Errorf("rule %q does not take an argument", name)
+		}
+
+		rules = append(rules, Rule{Name: name, Arg: arg})
+	}
+	return rules, false, nil
+}
+
+// Check returns an error if one of rules does not apply to values of typ, or if its argument is
+// not valid for them, such as a length that is not an integer or a number that overflows typ.
+func Check(rules []Rule, typ Type) error {
+	for _, rule := range rules {
+		if !slices.Contains(supported[typ.Kind], rule.Name) {
+			if typ.Kind == KindStruct && rule.Name == Required {
+				return fmt.Errorf("rule %q is not supported on struct values, use a pointer", rule.Name)
+			}
+			return fmt.Errorf("rule %q is not supported for %s values", rule.Name, typ.Name)
+		}
+		if rule.Name == Required {
+			continue
+		}
+
+		var err error
+		switch typ.Kind {
+		case KindString, KindCollection:
+			if rule.Name == Min || rule.Name == Max || rule.Name == Len {
+				_, err = rule.Length()
+			}
+		case KindInt:
+			_, err = rule.Int(typ.Bits)
+		case KindUint:
+			_, err = rule.Uint(typ.Bits)
+		case KindFloat:
+			_, err = rule.Float(typ.Bits)
+		case KindOther, KindStruct:
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Length returns the argument of a length rule, such as the min rule of a string.
+func (r Rule) Length() (int, error) {
+	n, err := strconv.Atoi(r.Arg)
+	if err != nil {
+		return 0, fmt.Errorf("rule %q: invalid integer %q", r.Name, r.Arg)
+	}
+	return n, nil
+}
+
+// Int returns the argument of a rule of a signed integer of the given bit size.
+func (r Rule) Int(bitSize int) (int64, error) {
+	n, err := strconv.ParseInt(r.Arg, 10, bitSize)
+	if err != nil {
+		return 0, r.invalidNumber(bitSize)
+	}
+	return n, nil
+}
+
+// Uint returns the argument of a rule of an unsigned integer of the given bit size.
+func (r Rule) Uint(bitSize int) (uint64, error) {
+	n, err := strconv.ParseUint(r.Arg, 10, bitSize)
+	if err != nil {
+		return 0, r.invalidNumber(bitSize)
+	}
+	return n, nil
+}
+
+// Float returns the argument of a rule of a float of the given bit size. NaN and infinities are
+// rejected, as every comparison with them is false.
+func (r Rule) Float(bitSize int) (float64, error) {
+	f, err := strconv.ParseFloat(r.Arg, bitSize)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, r.invalidNumber(bitSize)
+	}
+	return f, nil
+}
+
+// invalidNumber returns the error of a numeric argument that is not a number of the given bit size.
+func (r Rule) invalidNumber(bitSize int) error {
+	return fmt.Errorf("rule %q: invalid %d-bit number %q", r.Name, bitSize, r.Arg)
+}
+
+// FieldName returns the error key of a struct field from its json tag, falling back to the
+// field name. The second return value is true if the field is skipped with `json:"-"`.
+func FieldName(fieldName, jsonTag string) (string, bool) {
+	name, _, _ := strings.Cut(jsonTag, ",")
+	switch name {
+	case "-":
+		if jsonTag == "-" {
+			return "", true
+		}
+	case "":
+		return fieldName, false
+	}
+	return name, false
+}
@@ -0,0 +1,191 @@
+package structtag_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/internal/structtag"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected []structtag.Rule
+		skip     bool
+		wantErr  bool
+	}{
+		{
+			name: "empty tag",
+			tag:  "",
+		},
+		{
+			name: "skipped field",
+			tag:  "-",
+			skip: true,
+		},
+		{
+			name: "rules with and without arguments",
+			tag:  "required, min=3,max=20",
+			expected: []structtag.Rule{
+				{Name: "required"},
+				{Name: "min", Arg: "3"},
+				{Name: "max", Arg: "20"},
+			},
+		},
+		{
+			name:     "set argument",
+			tag:      "in=small|medium|large",
+			expected: []structtag.Rule{{Name: "in", Arg: "small|medium|large"}},
+		},
+		{
+			name:    "unknown rule",
+			tag:     "required,email",
+			wantErr: true,
+		},
+		{
+			name:    "missing argument",
+			tag:     "min",
+			wantErr: true,
+		},
+		{
+			name:    "unexpected argument",
+			tag:     "required=true",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rules, skip, err := structtag.Parse(tt.tag)
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if skip != tt.skip {
+				t.Errorf("expected skip: %v, got: %v", tt.skip, skip)
+			}
+			if !reflect.DeepEqual(rules, tt.expected) {
+				t.Errorf("expected rules %v, got %v", tt.expected, rules)
+			}
+		})
+	}
+
+	t.Run("unknown rules are reported with ErrUnknownRule", func(t *testing.T) {
+		_, _, err := structtag.Parse("email")
+		if !errors.Is(err, structtag.ErrUnknownRule) {
+			t.Errorf("expected ErrUnknownRule, got %v", err)
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		typ     structtag.Type
+		wantErr bool
+	}{
+		{
+			name: "string rules",
+			tag:  "required,min=3,in=a|b",
+			typ:  structtag.Type{Kind: structtag.KindString},
+		},
+		{
+			name: "numeric rules",
+			tag:  "required,gt=0,lte=10.5",
+			typ:  structtag.Type{Kind: structtag.KindFloat, Bits: 64},
+		},
+		{
+			name: "length rules of collections",
+			tag:  "required,len=2",
+			typ:  structtag.Type{Kind: structtag.KindCollection},
+		},
+		{
+			name:    "set rules of collections",
+			tag:     "in=a|b",
+			typ:     structtag.Type{Kind: structtag.KindCollection},
+			wantErr: true,
+		},
+		{
+			name:    "invalid length",
+			tag:     "min=three",
+			typ:     structtag.Type{Kind: structtag.KindString},
+			wantErr: true,
+		},
+		{
+			name:    "number overflowing the bit size",
+			tag:     "min=300",
+			typ:     structtag.Type{Kind: structtag.KindInt, Bits: 8},
+			wantErr: true,
+		},
+		{
+			name:    "negative unsigned number",
+			tag:     "min=-1",
+			typ:     structtag.Type{Kind: structtag.KindUint, Bits: 64},
+			wantErr: true,
+		},
+		{
+			name:    "non-finite float",
+			tag:     "max=NaN",
+			typ:     structtag.Type{Kind: structtag.KindFloat, Bits: 64},
+			wantErr: true,
+		},
+		{
+			name: "required on other values",
+			tag:  "required",
+			typ:  structtag.Type{Kind: structtag.KindOther},
+		},
+		{
+			name:    "required on structs",
+			tag:     "required",
+			typ:     structtag.Type{Kind: structtag.KindStruct},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			rules, _, err := structtag.Parse(tt.tag)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Act
+			err = structtag.Check(rules, tt.typ)
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	tests := []struct {
+		jsonTag  string
+		expected string
+		skip     bool
+	}{
+		{jsonTag: "", expected: "Field"},
+		{jsonTag: "field", expected: "field"},
+		{jsonTag: "field,omitempty", expected: "field"},
+		{jsonTag: ",omitempty", expected: "Field"},
+		{jsonTag: "-", skip: true},
+		{jsonTag: "-,", expected: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jsonTag, func(t *testing.T) {
+			name, skip := structtag.FieldName("Field", tt.jsonTag)
+			if name != tt.expected || skip != tt.skip {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.skip, name, skip)
+			}
+		})
+	}
+}
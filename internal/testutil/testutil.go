@@ -1,7 +1,11 @@
 package testutil
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/cachesdev/souuup/u"
 )
 
 // CheckError is a helper function to check if an error matches expected.
@@ -18,3 +22,23 @@ func CheckError(t *testing.T, err error, wantErr bool, errorMsg string) {
 		t.Errorf("expected error message: %q, got: %q", errorMsg, err.Error())
 	}
 }
+
+// CheckRuleError is a helper function to check that an error is a RuleError with
+// the expected code and params.
+func CheckRuleError(t *testing.T, err error, code string, params u.Params) {
+	t.Helper()
+
+	var re u.RuleError
+	if !errors.As(err, &re) {
+		t.Errorf("expected a RuleError, got: %v", err)
+		return
+	}
+
+	if re.Code != code {
+		t.Errorf("expected error code: %q, got: %q", code, re.Code)
+	}
+
+	if !reflect.DeepEqual(re.Params, params) {
+		t.Errorf("expected error params: %v, got: %v", params, re.Params)
+	}
+}
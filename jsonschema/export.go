@@ -0,0 +1,390 @@
+package jsonschema
+
+import (
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+// ExtensionRules is the extension keyword listing the rules of a field that have no JSON Schema
+// equivalent, such as custom rules, as their descriptors.
+const ExtensionRules = "x-souuup-rules"
+
+// JSON Schema type names.
+const (
+	TypeNull    = "null"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeString  = "string"
+)
+
+// Export returns the JSON Schema document of a schema. Nested schemas are exported as objects
+// with properties, and fields as the JSON Schema type of their Go type with the keywords of
+// their rules. Fields with a r.NotZero rule are required. Rules without a JSON Schema
+// equivalent are listed in the ExtensionRules keyword of their field.
+//
+// Example:
+//
+//	doc := jsonschema.Export(u.Schema{
+//		"username": u.Field(reg.Username, r.NotZero, r.MinS(3)),
+//	})
+//	// {
+//	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+//	//   "type": "object",
+//	//   "properties": {"username": {"type": "string", "minLength": 3}},
+//	//   "required": ["username"]
+//	// }
+func Export(schema u.Describer) *Schema {
+	doc := fromDescription(schema.Describe())
+	doc.Schema = Draft
+	return doc
+}
+
+// fromDescription converts a field description to a JSON Schema.
+func fromDescription(desc u.FieldDescription) *Schema {
+	if desc.Type == nil {
+		s := &Schema{}
+		if desc.Fields != nil {
+			s = objectSchema(desc.Fields)
+		}
+		// Schema rules have no JSON Schema equivalent
+		for _, rule := range desc.Rules {
+			addExtension(s, rule)
+		}
+		return s
+	}
+
+	s := typeSchema(desc.Type)
+	if desc.Fields != nil {
+		switch deref(desc.Type).Kind() {
+		case reflect.Slice, reflect.Array:
+			s.Items = elementSchema(s.Items, desc.Fields)
+		case reflect.Map:
+			s.AdditionalProperties = elementSchema(s.AdditionalProperties, desc.Fields)
+		default:
+			nested := objectSchema(desc.Fields)
+			s.Type, s.Properties, s.Required = nested.Type, nested.Properties, nested.Required
+		}
+	}
+
+	for _, rule := range desc.Rules {
+		applyRule(s, desc.Type, rule)
+	}
+	return s
+}
+
+// objectSchema returns the object schema of the fields of a schema.
+func objectSchema(fields []u.FieldDescription) *Schema {
+	s := &Schema{Type: Types{TypeObject}}
+	for _, field := range fields {
+		if s.Properties == nil {
+			s.Properties = make(map[string]*Schema, len(fields))
+		}
+		s.Properties[field.Tag] = fromDescription(field)
+
+		if isRequired(field) {
+			s.Required = append(s.Required, field.Tag)
+		}
+	}
+	return s
+}
+
+// elementSchema returns the schema of the elements of a collection validated by a nested schema
+// keyed by index or key, which is the schema of its first element.
+func elementSchema(items *Schema, fields []u.FieldDescription) *Schema {
+	if len(fields) == 0 {
+		return items
+	}
+	return fromDescription(fields[0])
+}
+
+// isRequired reports whether a field is required by its rules.
+func isRequired(field u.FieldDescription) bool {
+	for _, rule := range field.Rules {
+		if rule.Name == r.CodeNotZero || rule.Name == r.CodeRequired {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSchema returns the schema of a Go type. Pointers are nullable, and the elements of slices,
+// arrays and maps are described by the items and additionalProperties keywords. Times are
+// strings in the date-time format, as they are encoded by encoding/json.
+func typeSchema(t reflect.Type) *Schema {
+	if t == reflect.TypeFor[time.Time]() {
+		return &Schema{Type: Types{TypeString}, Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := typeSchema(t.Elem())
+		if len(s.Type) > 0 {
+			s.Type = append(s.Type, TypeNull)
+		}
+		return s
+	case reflect.String:
+		return &Schema{Type: Types{TypeString}}
+	case reflect.Bool:
+		return &Schema{Type: Types{TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Types{TypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{TypeNumber}}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: Types{TypeArray}, Items: typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{TypeObject}, AdditionalProperties: typeSchema(t.Elem())}
+	case reflect.Struct:
+		return &Schema{Type: Types{TypeObject}}
+	case reflect.Interface, reflect.Complex64, reflect.Complex128, reflect.Chan, reflect.Func,
+		reflect.UnsafePointer, reflect.Invalid:
+	}
+	return &Schema{}
+}
+
+// applyRule adds the keywords of a rule to the schema of a field of type t.
+func applyRule(s *Schema, t reflect.Type, rule u.RuleDescriptor) {
+	switch rule.Name {
+	case r.CodeNotZero:
+		applyNotZero(s, t)
+	case r.CodeRequired:
+		s.Type = removeNull(s.Type)
+	case r.CodeNull:
+		*s = Schema{Type: Types{TypeNull}}
+	case u.CodePtr:
+		rules, _ := rule.Params["rules"].([]u.RuleDescriptor)
+		if t.Kind() != reflect.Pointer {
+			addExtension(s, rule)
+			return
+		}
+		for _, elem := range rules {
+			applyRule(s, t.Elem(), elem)
+		}
+
+	case r.CodeMinS:
+		s.MinLength = intParam(rule, "min")
+	case r.CodeMaxS:
+		s.MaxLength = intParam(rule, "max")
+	case r.CodeLenS:
+		s.MinLength, s.MaxLength = intParam(rule, "length"), intParam(rule, "length")
+	case r.CodeInS:
+		s.Enum = anySlice(rule.Params["set"])
+	case r.CodeNotInS:
+		addNot(s, &Schema{Enum: anySlice(rule.Params["set"])})
+	case r.CodeContainsS:
+		substr, _ := rule.Params["substring"].(string)
+		addPattern(s, regexp.QuoteMeta(substr))
+
+	case r.CodeMinN:
+		s.Minimum = numberParam(rule, "min")
+	case r.CodeMaxN:
+		s.Maximum = numberParam(rule, "max")
+	case r.CodeGt:
+		s.ExclusiveMinimum = numberParam(rule, "limit")
+	case r.CodeLt:
+		s.ExclusiveMaximum = numberParam(rule, "limit")
+	case r.CodeNeqN:
+		addNot(s, &Schema{Const: rule.Params["value"]})
+
+	case r.CodeMinLen:
+		s.MinItems = intParam(rule, "min")
+	case r.CodeMaxLen:
+		s.MaxItems = intParam(rule, "max")
+	case r.CodeExactLen:
+		s.MinItems, s.MaxItems = intParam(rule, "length"), intParam(rule, "length")
+	case r.CodeContains:
+		s.Contains = &Schema{Const: rule.Params["member"]}
+	case r.CodeEvery:
+		elem, ok := rule.Params["rule"].(u.RuleDescriptor)
+		if !ok || deref(t).Kind() != reflect.Slice && deref(t).Kind() != reflect.Array {
+			addExtension(s, rule)
+			return
+		}
+		if s.Items == nil {
+			s.Items = typeSchema(deref(t).Elem())
+		}
+		applyRule(s.Items, deref(t).Elem(), elem)
+
+	case r.CodeMinKeys:
+		s.MinProperties = intParam(rule, "min")
+	case r.CodeMaxKeys:
+		s.MaxProperties = intParam(rule, "max")
+	case r.CodeRequiredKey:
+		keys, ok := rule.Params["keys"].([]string)
+		if !ok {
+			addExtension(s, rule)
+			return
+		}
+		s.Required = append(s.Required, keys...)
+	case r.CodeValues:
+		elem, ok := rule.Params["rule"].(u.RuleDescriptor)
+		if !ok || deref(t).Kind() != reflect.Map {
+			addExtension(s, rule)
+			return
+		}
+		if s.AdditionalProperties == nil {
+			s.AdditionalProperties = typeSchema(deref(t).Elem())
+		}
+		applyRule(s.AdditionalProperties, deref(t).Elem(), elem)
+
+	case r.CodeAnd:
+		for _, child := range ruleParams(rule) {
+			applyRule(s, t, child)
+		}
+	case r.CodeOr:
+		s.AnyOf = append(s.AnyOf, subSchemas(t, ruleParams(rule))...)
+	case r.CodeXor, r.CodeOneOf:
+		s.OneOf = append(s.OneOf, subSchemas(t, ruleParams(rule))...)
+	case r.CodeNot:
+		child, ok := rule.Params["rule"].(u.RuleDescriptor)
+		if !ok {
+			addExtension(s, rule)
+			return
+		}
+		addNot(s, subSchemas(t, []u.RuleDescriptor{child})[0])
+
+	default:
+		addExtension(s, rule)
+	}
+}
+
+// ruleParams returns the descriptors of the rules combined by a rule.
+func ruleParams(rule u.RuleDescriptor) []u.RuleDescriptor {
+	rules, _ := rule.Params["rules"].([]u.RuleDescriptor)
+	return rules
+}
+
+// subSchemas returns a schema with the keywords of each rule, for the values of type t.
+func subSchemas(t reflect.Type, rules []u.RuleDescriptor) []*Schema {
+	schemas := make([]*Schema, len(rules))
+	for i, rule := range rules {
+		schemas[i] = &Schema{}
+		applyRule(schemas[i], t, rule)
+	}
+	return schemas
+}
+
+// applyNotZero adds the keywords excluding the zero value of a type.
+func applyNotZero(s *Schema, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		s.Type = removeNull(s.Type)
+	case reflect.String:
+		if s.MinLength == nil || *s.MinLength < 1 {
+			s.MinLength = ptr(1)
+		}
+	case reflect.Bool:
+		s.Const = true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		addNot(s, &Schema{Const: 0})
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Invalid:
+	}
+}
+
+// addPattern adds a pattern the value must match, using allOf if the schema already has one.
+func addPattern(s *Schema, pattern string) {
+	if s.Pattern == "" {
+		s.Pattern = pattern
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Pattern: pattern})
+}
+
+// addNot adds a schema the value must not match, using allOf if the schema already has one.
+func addNot(s *Schema, not *Schema) {
+	if s.Not == nil {
+		s.Not = not
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Not: not})
+}
+
+// addExtension lists a rule in the ExtensionRules keyword of a schema.
+func addExtension(s *Schema, rule u.RuleDescriptor) {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]any)
+	}
+	rules, _ := s.Extensions[ExtensionRules].([]u.RuleDescriptor)
+	s.Extensions[ExtensionRules] = append(rules, rule)
+}
+
+// removeNull removes the null type from a list of types.
+func removeNull(types Types) Types {
+	var result Types
+	for _, typ := range types {
+		if typ != TypeNull {
+			result = append(result, typ)
+		}
+	}
+	return result
+}
+
+// deref returns the type pointed to by pointer types.
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// intParam returns an integer param of a rule.
+func intParam(rule u.RuleDescriptor, name string) *int {
+	n, ok := toFloat(rule.Params[name])
+	if !ok {
+		return nil
+	}
+	return ptr(int(n))
+}
+
+// numberParam returns a numeric param of a rule.
+func numberParam(rule u.RuleDescriptor, name string) *float64 {
+	n, ok := toFloat(rule.Params[name])
+	if !ok {
+		return nil
+	}
+	return &n
+}
+
+// toFloat converts a numeric value to a float64.
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// anySlice converts a slice of any type to a []any.
+func anySlice(v any) []any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	result := make([]any, rv.Len())
+	for i := range result {
+		result[i] = rv.Index(i).Interface()
+	}
+	return result
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
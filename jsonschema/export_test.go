@@ -0,0 +1,209 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cachesdev/souuup/internal/testutil"
+	"github.com/cachesdev/souuup/jsonschema"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestExport(t *testing.T) {
+	nickname := "johnny"
+
+	tests := []struct {
+		name     string
+		schema   u.Describer
+		expected string
+	}{
+		{
+			name: "string rules",
+			schema: u.Schema{
+				"username": u.Field("johndoe", r.NotZero, r.MinS(3), r.MaxS(20)),
+				"otp":      u.Field("123456", r.LenS(6)),
+				"size":     u.Field("small", r.InS([]string{"small", "large"})),
+				"status":   u.Field("done", r.NotInS([]string{"invalid"})),
+				"street":   u.Field("Main St.", r.ContainsS("St."), r.ContainsS("Main")),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"otp":{"type":"string","minLength":6,"maxLength":6},` +
+				`"size":{"type":"string","enum":["small","large"]},` +
+				`"status":{"type":"string","not":{"enum":["invalid"]}},` +
+				`"street":{"type":"string","pattern":"St\\.","allOf":[{"pattern":"Main"}]},` +
+				`"username":{"type":"string","minLength":3,"maxLength":20}},` +
+				`"required":["username"]}`,
+		},
+		{
+			name: "numeric rules",
+			schema: u.Ordered(
+				u.Entry("age", u.Field(25, r.MinN(18), r.MaxN(120))),
+				u.Entry("discount", u.Field(0.5, r.Gt(0.0), r.Lt(1.0))),
+				u.Entry("qty", u.Field(uint(2), r.NotZero, r.NeqN[uint](1))),
+			),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"age":{"type":"integer","minimum":18,"maximum":120},` +
+				`"discount":{"type":"number","exclusiveMinimum":0,"exclusiveMaximum":1},` +
+				`"qty":{"type":"integer","not":{"const":0},"allOf":[{"not":{"const":1}}]}},` +
+				`"required":["qty"]}`,
+		},
+		{
+			name: "stacked exclusions",
+			schema: u.Ordered(
+				u.Entry("count", u.Field(3, r.NeqN(1), r.NeqN(2))),
+				u.Entry("status", u.Field("done", r.NotInS([]string{"invalid"}), r.Not(r.ContainsS("tmp")))),
+			),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"count":{"type":"integer","not":{"const":1},"allOf":[{"not":{"const":2}}]},` +
+				`"status":{"type":"string","not":{"enum":["invalid"]},"allOf":[{"not":{"pattern":"tmp"}}]}}}`,
+		},
+		{
+			name: "slice rules",
+			schema: u.Schema{
+				"tags":    u.Field([]string{"a"}, r.MinLen[string](1), r.MaxLen[string](5), r.Every(r.MinS(2))),
+				"members": u.Field([]string{"GK"}, r.ExactLen[string](1), r.Contains("GK")),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"members":{"type":"array","items":{"type":"string"},"minItems":1,"maxItems":1,"contains":{"const":"GK"}},` +
+				`"tags":{"type":"array","items":{"type":"string","minLength":2},"minItems":1,"maxItems":5}}}`,
+		},
+		{
+			name: "map rules",
+			schema: u.Schema{
+				"labels": u.Field(map[string]string{},
+					r.MinKeys[string, string](1), r.MaxKeys[string, string](10),
+					r.RequiredKeys[string, string]("app"), r.Values[string](r.MaxS(63)),
+					r.Keys[string, string](r.MinS(1)),
+				),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"labels":{"type":"object","required":["app"],"additionalProperties":{"type":"string","maxLength":63},` +
+				`"minProperties":1,"maxProperties":10,` +
+				`"x-souuup-rules":[{"name":"map.keys","params":{"rule":{"name":"string.min_length","params":{"min":1},` +
+				`"description":"must be at least 1 character long"}},"description":"every key must be at least 1 character long"}]}}}`,
+		},
+		{
+			name: "logical rules",
+			schema: u.Ordered(
+				u.Entry("code", u.Field("AB1234", r.And(r.LenS(6), r.ContainsS("AB")))),
+				u.Entry("id", u.Field("42", r.Or(r.LenS(36), r.MaxS(10)))),
+				u.Entry("status", u.Field("done", r.Not(r.InS([]string{"invalid"})))),
+				u.Entry("kind", u.Field("a", r.OneOf(r.InS([]string{"a"}), r.InS([]string{"b"})))),
+			),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"code":{"type":"string","minLength":6,"maxLength":6,"pattern":"AB"},` +
+				`"id":{"type":"string","anyOf":[{"minLength":36,"maxLength":36},{"maxLength":10}]},` +
+				`"kind":{"type":"string","oneOf":[{"enum":["a"]},{"enum":["b"]}]},` +
+				`"status":{"type":"string","not":{"enum":["invalid"]}}}}`,
+		},
+		{
+			name: "nested schemas, pointers and times",
+			schema: u.Schema{
+				"address": u.Schema{
+					"city": u.Field("London", r.NotZero),
+				},
+				"nickname":  u.Field(&nickname),
+				"createdAt": u.Field(time.Time{}),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"address":{"type":"object","properties":{"city":{"type":"string","minLength":1}},"required":["city"]},` +
+				`"createdAt":{"type":"string","format":"date-time"},` +
+				`"nickname":{"type":["string","null"]}}}`,
+		},
+		{
+			name: "optional and required pointers",
+			schema: u.Schema{
+				"nickname":    u.Optional(&nickname, r.MinS(3)),
+				"displayName": u.Field(&nickname, r.Required[string], u.Ptr(r.MaxS(20))),
+				"deletedAt":   u.Field((*time.Time)(nil), r.Null[time.Time]),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"deletedAt":{"type":"null"},` +
+				`"displayName":{"type":"string","maxLength":20},` +
+				`"nickname":{"type":["string","null"],"minLength":3}},` +
+				`"required":["displayName"]}`,
+		},
+		{
+			name: "slices of nested schemas",
+			schema: u.Schema{
+				"items": u.WithNested(
+					u.Field([]int{1}, r.MinLen[int](1)),
+					u.Ordered(u.Entry("0", u.Schema{"qty": u.Field(1, r.MinN(1))})),
+				),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"items":{"type":"array","items":{"type":"object","properties":{"qty":{"type":"integer","minimum":1}}},"minItems":1}}}`,
+		},
+		{
+			name: "custom rules as extension keywords",
+			schema: u.Schema{
+				"email": u.Field("a@b.c", validEmail, r.MaxS(50)),
+				"words": u.Field("a b", u.WithDescriptor(u.RuleDescriptor{Name: "myapp.min_words", Params: u.Params{"min": 2}},
+					func(u.FieldState[string]) error { return nil })),
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+				`"email":{"type":"string","maxLength":50,"x-souuup-rules":[{"name":"github.com/cachesdev/souuup/jsonschema_test.validEmail","opaque":true}]},` +
+				`"words":{"type":"string","x-souuup-rules":[{"name":"myapp.min_words","params":{"min":2}}]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			doc := jsonschema.Export(tt.schema)
+
+			// Assert
+			bytes, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatalf("unexpected marshalling error: %v", err)
+			}
+			if string(bytes) != tt.expected {
+				t.Errorf("expected JSON:\n%s\ngot:\n%s", tt.expected, string(bytes))
+			}
+		})
+	}
+}
+
+func TestExport_Each(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}
+	itemSchema := func(it item) u.Schema {
+		return u.Schema{"sku": u.Field(it.SKU, r.NotZero), "qty": u.Field(it.Qty, r.MinN(1))}
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+		`"items":{"type":"array","items":{"type":"object","properties":{` +
+		`"qty":{"type":"integer","minimum":1},"sku":{"type":"string","minLength":1}},"required":["sku"]}}}}`
+
+	for _, items := range [][]item{nil, {{SKU: "A", Qty: 1}, {SKU: "B", Qty: 2}}} {
+		// Act
+		doc := jsonschema.Export(u.Schema{"items": u.Each(items, itemSchema)})
+
+		// Assert
+		bytes, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		if string(bytes) != expected {
+			t.Errorf("expected JSON for %d items:\n%s\ngot:\n%s", len(items), expected, string(bytes))
+		}
+
+		v, err := jsonschema.Load(bytes)
+		if err != nil {
+			t.Fatalf("unexpected error loading the exported schema: %v", err)
+		}
+		if err := v.ValidateJSON([]byte(`{"items":[{"sku":"A","qty":1}]}`)); err != nil {
+			t.Errorf("expected the payload to be valid, got %v", err)
+		}
+		err = v.ValidateJSON([]byte(`{"items":[{"sku":"A","qty":1},{"qty":0}]}`))
+		testutil.CheckError(t, err, true,
+			`{"items":{"1":{"qty":{"errors":["value is 0, but needs to be at least 1"]},"sku":{"errors":["value is required"]}}}}`)
+	}
+}
+
+func validEmail(u.FieldState[string]) error {
+	return nil
+}
@@ -0,0 +1,617 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+// Error codes reported by imported schemas, for the keywords without an equivalent rule in the r package.
+const (
+	CodeType                 = u.CodeType
+	CodeEnum                 = "value.enum"
+	CodeConst                = "value.const"
+	CodeNot                  = "value.not"
+	CodeAnyOf                = "value.any_of"
+	CodeOneOf                = "value.one_of"
+	CodePattern              = "string.pattern"
+	CodeUniqueItems          = "slice.unique"
+	CodeContainsMatch        = "slice.contains_match"
+	CodeMinProperties        = "object.min_properties"
+	CodeMaxProperties        = "object.max_properties"
+	CodeAdditionalProperties = "object.additional_property"
+)
+
+// ErrUnsupportedKeyword is returned when loading a document that uses a keyword this package cannot validate.
+var ErrUnsupportedKeyword = errors.New("unsupported keyword")
+
+// annotationKeywords are the keywords that do not affect validation, and are ignored.
+var annotationKeywords = []string{
+	"$comment", "$vocabulary", "examples", "default", "deprecated", "readOnly", "writeOnly",
+	"contentEncoding", "contentMediaType",
+}
+
+// Validator validates untyped data, such as decoded JSON, against a JSON Schema document.
+// Failures are reported through the same ValidationError tree as hand-written schemas, at the
+// path of the offending value. A Validator is safe for concurrent use.
+type Validator struct {
+	root     *Schema
+	refs     map[string]*Schema
+	patterns map[*Schema]*regexp.Regexp
+}
+
+// Load parses a JSON Schema document and returns a validator for it. The supported keywords are
+// type, enum, const, properties, required, additionalProperties, minProperties, maxProperties,
+// items, minItems, maxItems, uniqueItems, contains, minLength, maxLength, pattern, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, not, allOf, anyOf, oneOf, and $ref to local
+// definitions in $defs. An error is returned for any other validation keyword.
+//
+// Example:
+//
+//	v, err := jsonschema.Load(contract)
+//	if err != nil {
+//		return err
+//	}
+//
+//	var payload map[string]any
+//	_ = json.Unmarshal(body, &payload)
+//	err = v.Validate(payload)
+func Load(data []byte) (*Validator, error) {
+	var root Schema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("jsonschema: parsing document: %w", err)
+	}
+	return New(&root)
+}
+
+// New returns a validator for a JSON Schema document, such as one returned by Export.
+func New(root *Schema) (*Validator, error) {
+	v := &Validator{
+		root:     root,
+		refs:     make(map[string]*Schema),
+		patterns: make(map[*Schema]*regexp.Regexp),
+	}
+	if err := v.compile(root, "#"); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return v, nil
+}
+
+// Bind returns the validatable entity of data, which can be validated on its own or nested in
+// another schema. Objects are validated as nested schemas keyed by property name, and arrays
+// as nested schemas keyed by index.
+//
+// Example:
+//
+//	schema := u.Schema{
+//		"user":    u.Field(user.Name, r.MinS(3)),
+//		"payload": v.Bind(payload),
+//	}
+func (v *Validator) Bind(data any) u.Object {
+	return &document{instance{v: v, schema: v.root, value: data}}
+}
+
+// Validate validates data against the document, with the given validation options.
+func (v *Validator) Validate(data any, opts ...u.Option) error {
+	return u.NewSouuup(v.Bind(data)).Validate(opts...)
+}
+
+// ValidateJSON decodes a JSON document and validates it.
+func (v *Validator) ValidateJSON(data []byte, opts ...u.Option) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return v.Validate(value, opts...)
+}
+
+// compile checks the keywords of a schema and its subschemas, resolves their references and
+// compiles their patterns. location is the JSON Pointer fragment of the schema in the document.
+func (v *Validator) compile(s *Schema, location string) error {
+	if s == nil {
+		return nil
+	}
+
+	for keyword := range s.Extensions {
+		if !strings.HasPrefix(keyword, "x-") && !slices.Contains(annotationKeywords, keyword) {
+			return fmt.Errorf("%s: %w %q", location, ErrUnsupportedKeyword, keyword)
+		}
+	}
+
+	if s.Ref != "" {
+		target, err := v.resolve(s.Ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		v.refs[s.Ref] = target
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", location, err)
+		}
+		v.patterns[s] = re
+	}
+
+	for name, def := range s.Defs {
+		if err := v.compile(def, location+"/$defs/"+escape(name)); err != nil {
+			return err
+		}
+	}
+	for name, prop := range s.Properties {
+		if err := v.compile(prop, location+"/properties/"+escape(name)); err != nil {
+			return err
+		}
+	}
+
+	subschemas := map[string]*Schema{
+		"additionalProperties": s.AdditionalProperties,
+		"items":                s.Items,
+		"contains":             s.Contains,
+		"not":                  s.Not,
+	}
+	for keyword, sub := range subschemas {
+		if err := v.compile(sub, location+"/"+keyword); err != nil {
+			return err
+		}
+	}
+
+	lists := map[string][]*Schema{"allOf": s.AllOf, "anyOf": s.AnyOf, "oneOf": s.OneOf}
+	for keyword, list := range lists {
+		for i, sub := range list {
+			if err := v.compile(sub, location+"/"+keyword+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve resolves a reference to a schema of the document. Only references to the document
+// itself, such as "#/$defs/address", are supported.
+func (v *Validator) resolve(ref string) (*Schema, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only references within the document are supported", ref)
+	}
+
+	s := v.root
+	if pointer == "" {
+		return s, nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i := 0; i < len(segments) && s != nil; i++ {
+		segment := unescape(segments[i])
+
+		switch segment {
+		case "$defs", "properties":
+			if i+1 == len(segments) {
+				return nil, fmt.Errorf("unresolvable $ref %q", ref)
+			}
+			i++
+			if segment == "$defs" {
+				s = s.Defs[unescape(segments[i])]
+			} else {
+				s = s.Properties[unescape(segments[i])]
+			}
+		case "items":
+			s = s.Items
+		case "additionalProperties":
+			s = s.AdditionalProperties
+		case "contains":
+			s = s.Contains
+		case "not":
+			s = s.Not
+		default:
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("unresolvable $ref %q", ref)
+	}
+	return s, nil
+}
+
+// escape escapes a JSON Pointer segment.
+func escape(segment string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+}
+
+// unescape unescapes a JSON Pointer segment.
+func unescape(segment string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+}
+
+// document is the root instance of a document. It reports the failures of the root value under
+// u.RootTag, and the failures of its properties or elements directly in the ValidationError.
+type document struct {
+	instance
+}
+
+var _ u.Object = (*document)(nil)
+
+// Validate implements the u.Validable interface for document.
+func (d *document) Validate(ve *u.ValidationError, _ u.FieldTag) {
+	d.validate(ve, u.RootTag, func() *u.ValidationError { return ve })
+}
+
+// Entries implements the u.Object interface for document, returning the instances of the
+// properties or elements of the root value.
+func (d *document) Entries() []u.SchemaEntry {
+	var entries []u.SchemaEntry
+	for _, child := range d.children() {
+		entries = append(entries, u.Entry(child.tag, child.instance))
+	}
+	return entries
+}
+
+// Errors implements the u.Validable interface for document.
+func (d *document) Errors() *u.ValidationError {
+	ve := u.NewValidationError()
+	d.Validate(ve, "")
+	return ve
+}
+
+// instance is a value validated against a schema of a document.
+type instance struct {
+	v      *Validator
+	schema *Schema
+	value  any
+}
+
+var _ u.Validable = (*instance)(nil)
+
+// child is the instance of a property or element of a value.
+type child struct {
+	tag      u.FieldTag
+	instance *instance
+}
+
+// Validate implements the u.Validable interface for instance. The failures of the value are
+// reported under tag, and the failures of its properties or elements in the nested errors of tag.
+func (in *instance) Validate(ve *u.ValidationError, tag u.FieldTag) {
+	in.validate(ve, tag, func() *u.ValidationError { return ve.GetOrCreateNested(tag) })
+}
+
+// Errors implements the u.Validable interface for instance.
+func (in *instance) Errors() *u.ValidationError {
+	ve := u.NewValidationError()
+	in.Validate(ve, "")
+	return ve
+}
+
+// validate validates the value, reporting its failures in ve under tag, and the failures of its
+// properties or elements in the ValidationError returned by nested.
+func (in *instance) validate(ve *u.ValidationError, tag u.FieldTag, nested func() *u.ValidationError) {
+	s := in.schema
+
+	if s.Ref != "" {
+		ref := &instance{v: in.v, schema: in.v.refs[s.Ref], value: in.value}
+		ref.validate(ve, tag, nested)
+	}
+
+	for _, sub := range s.AllOf {
+		(&instance{v: in.v, schema: sub, value: in.value}).validate(ve, tag, nested)
+	}
+
+	typ := jsonType(in.value)
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return typeMatches(t, typ) }) {
+		ve.AddError(tag, u.NewRuleError(CodeType, u.Params{"expected": s.Type.String(), "actual": typ}))
+		return
+	}
+
+	for _, err := range in.valueErrors(typ) {
+		ve.AddError(tag, err)
+	}
+
+	for _, c := range in.children() {
+		c.instance.Validate(nested(), c.tag)
+	}
+
+	if typ == TypeObject {
+		in.validateProperties(nested)
+	}
+}
+
+// valueErrors returns the failures of the keywords that apply to the value as a whole.
+func (in *instance) valueErrors(typ string) []error {
+	s := in.schema
+	var errs []error
+
+	if s.Enum != nil && !slices.ContainsFunc(s.Enum, func(e any) bool { return equal(e, in.value) }) {
+		errs = append(errs, u.NewRuleError(CodeEnum, u.Params{"set": s.Enum, "actual": in.value}))
+	}
+	if s.Const != nil && !equal(s.Const, in.value) {
+		errs = append(errs, u.NewRuleError(CodeConst, u.Params{"value": s.Const, "actual": in.value}))
+	}
+
+	switch typ {
+	case TypeString:
+		errs = append(errs, in.stringErrors()...)
+	case TypeNumber, TypeInteger:
+		errs = append(errs, in.numberErrors()...)
+	case TypeArray:
+		errs = append(errs, in.arrayErrors()...)
+	case TypeObject:
+		errs = append(errs, in.objectErrors()...)
+	}
+
+	if s.Not != nil && in.matches(s.Not) {
+		errs = append(errs, u.NewRuleError(CodeNot, nil))
+	}
+
+	if len(s.AnyOf) > 0 && !slices.ContainsFunc(s.AnyOf, in.matches) {
+		errs = append(errs, u.NewRuleError(CodeAnyOf, u.Params{"count": len(s.AnyOf)}))
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if in.matches(sub) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			errs = append(errs, u.NewRuleError(CodeOneOf, u.Params{"matched": matched, "count": len(s.OneOf)}))
+		}
+	}
+	return errs
+}
+
+// stringErrors returns the failures of the string keywords. Lengths are counted in characters.
+func (in *instance) stringErrors() []error {
+	s := in.schema
+	value := reflect.ValueOf(in.value).String()
+	length := utf8.RuneCountInString(value)
+
+	var errs []error
+	if s.MinLength != nil && length < *s.MinLength {
+		errs = append(errs, u.NewRuleError(r.CodeMinS, u.Params{"min": *s.MinLength, "actual": length}))
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		errs = append(errs, u.NewRuleError(r.CodeMaxS, u.Params{"max": *s.MaxLength, "actual": length}))
+	}
+	if re := in.v.patterns[s]; re != nil && !re.MatchString(value) {
+		errs = append(errs, u.NewRuleError(CodePattern, u.Params{"pattern": s.Pattern, "actual": value}))
+	}
+	return errs
+}
+
+// numberErrors returns the failures of the numeric keywords.
+func (in *instance) numberErrors() []error {
+	s := in.schema
+	value, _ := toNumber(in.value)
+
+	var errs []error
+	if s.Minimum != nil && value < *s.Minimum {
+		errs = append(errs, u.NewRuleError(r.CodeMinN, u.Params{"min": *s.Minimum, "actual": value}))
+	}
+	if s.Maximum != nil && value > *s.Maximum {
+		errs = append(errs, u.NewRuleError(r.CodeMaxN, u.Params{"max": *s.Maximum, "actual": value}))
+	}
+	if s.ExclusiveMinimum != nil && value <= *s.ExclusiveMinimum {
+		errs = append(errs, u.NewRuleError(r.CodeGt, u.Params{"limit": *s.ExclusiveMinimum, "actual": value}))
+	}
+	if s.ExclusiveMaximum != nil && value >= *s.ExclusiveMaximum {
+		errs = append(errs, u.NewRuleError(r.CodeLt, u.Params{"limit": *s.ExclusiveMaximum, "actual": value}))
+	}
+	return errs
+}
+
+// arrayErrors returns the failures of the array keywords.
+func (in *instance) arrayErrors() []error {
+	s := in.schema
+	rv := reflect.ValueOf(in.value)
+	length := rv.Len()
+
+	var errs []error
+	if s.MinItems != nil && length < *s.MinItems {
+		errs = append(errs, u.NewRuleError(r.CodeMinLen, u.Params{"min": *s.MinItems, "actual": length}))
+	}
+	if s.MaxItems != nil && length > *s.MaxItems {
+		errs = append(errs, u.NewRuleError(r.CodeMaxLen, u.Params{"max": *s.MaxItems, "actual": length}))
+	}
+
+	if s.UniqueItems {
+	unique:
+		for i := range length {
+			for j := range i {
+				if equal(rv.Index(i).Interface(), rv.Index(j).Interface()) {
+					errs = append(errs, u.NewRuleError(CodeUniqueItems, u.Params{"index": i, "duplicate": j}))
+					break unique
+				}
+			}
+		}
+	}
+
+	if s.Contains != nil {
+		found := false
+		for i := 0; i < length && !found; i++ {
+			found = (&instance{v: in.v, value: rv.Index(i).Interface()}).matches(s.Contains)
+		}
+		if !found {
+			errs = append(errs, u.NewRuleError(CodeContainsMatch, nil))
+		}
+	}
+	return errs
+}
+
+// objectErrors returns the failures of the keywords that apply to the number of properties.
+func (in *instance) objectErrors() []error {
+	s := in.schema
+	count := reflect.ValueOf(in.value).Len()
+
+	var errs []error
+	if s.MinProperties != nil && count < *s.MinProperties {
+		errs = append(errs, u.NewRuleError(CodeMinProperties, u.Params{"min": *s.MinProperties, "actual": count}))
+	}
+	if s.MaxProperties != nil && count > *s.MaxProperties {
+		errs = append(errs, u.NewRuleError(CodeMaxProperties, u.Params{"max": *s.MaxProperties, "actual": count}))
+	}
+	return errs
+}
+
+// validateProperties reports missing required properties, and properties not allowed by
+// additionalProperties, in the ValidationError returned by nested.
+func (in *instance) validateProperties(nested func() *u.ValidationError) {
+	s := in.schema
+	rv := reflect.ValueOf(in.value)
+
+	for _, name := range s.Required {
+		if !rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())).IsValid() {
+			nested().AddError(name, u.NewRuleError(r.CodeRequired, nil))
+		}
+	}
+
+	if s.AdditionalProperties == nil || !isFalse(s.AdditionalProperties) {
+		return
+	}
+	for _, name := range sortedKeys(rv) {
+		if _, declared := s.Properties[name]; !declared {
+			nested().AddError(name, u.NewRuleError(CodeAdditionalProperties, nil))
+		}
+	}
+}
+
+// children returns the instances of the properties of an object, sorted by name, or of the
+// elements of an array, that have a schema.
+func (in *instance) children() []child {
+	s := in.schema
+	rv := reflect.ValueOf(in.value)
+
+	var children []child
+	switch jsonType(in.value) {
+	case TypeObject:
+		for _, name := range sortedKeys(rv) {
+			sub, declared := s.Properties[name]
+			if !declared {
+				// Properties not allowed by a false additionalProperties are reported by validateProperties
+				sub = s.AdditionalProperties
+				if sub == nil || isFalse(sub) {
+					continue
+				}
+			}
+			value := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())).Interface()
+			children = append(children, child{tag: name, instance: &instance{v: in.v, schema: sub, value: value}})
+		}
+	case TypeArray:
+		if s.Items == nil {
+			return nil
+		}
+		for i := range rv.Len() {
+			value := rv.Index(i).Interface()
+			children = append(children, child{tag: strconv.Itoa(i), instance: &instance{v: in.v, schema: s.Items, value: value}})
+		}
+	}
+	return children
+}
+
+// matches reports whether the value is valid against a subschema.
+func (in *instance) matches(sub *Schema) bool {
+	scratch := u.NewValidationError()
+	(&instance{v: in.v, schema: sub, value: in.value}).Validate(scratch, u.RootTag)
+	return !scratch.HasErrors()
+}
+
+// isFalse reports whether a schema is the false schema, which matches nothing.
+func isFalse(s *Schema) bool {
+	return s.Not != nil && reflect.DeepEqual(*s.Not, Schema{}) && reflect.DeepEqual(*s, Schema{Not: s.Not})
+}
+
+// jsonType returns the JSON type of a value, using TypeInteger for whole numbers.
+// It returns an empty string for values that have no JSON equivalent.
+func jsonType(value any) string {
+	if value == nil {
+		return TypeNull
+	}
+	if n, ok := toNumber(value); ok {
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			return TypeInteger
+		}
+		return TypeNumber
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return TypeBoolean
+	case reflect.String:
+		return TypeString
+	case reflect.Slice, reflect.Array:
+		return TypeArray
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return TypeObject
+		}
+	default:
+	}
+	return ""
+}
+
+// typeMatches reports whether a value of the JSON type actual is valid against the type expected.
+func typeMatches(expected, actual string) bool {
+	return expected == actual || expected == TypeNumber && actual == TypeInteger
+}
+
+// toNumber converts a numeric value, including a json.Number, to a float64.
+func toNumber(value any) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return toFloat(value)
+}
+
+// equal reports whether two values are equal as JSON values, regardless of their numeric types.
+func equal(a, b any) bool {
+	return reflect.DeepEqual(normalise(a), normalise(b))
+}
+
+// normalise converts numbers to float64, objects to map[string]any and arrays to []any.
+func normalise(value any) any {
+	if n, ok := toNumber(value); ok {
+		return n
+	}
+
+	rv := reflect.ValueOf(value)
+	switch jsonType(value) {
+	case TypeObject:
+		result := make(map[string]any, rv.Len())
+		for _, name := range sortedKeys(rv) {
+			result[name] = normalise(rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())).Interface())
+		}
+		return result
+	case TypeArray:
+		result := make([]any, rv.Len())
+		for i := range result {
+			result[i] = normalise(rv.Index(i).Interface())
+		}
+		return result
+	case TypeString:
+		return rv.String()
+	}
+	return value
+}
+
+// sortedKeys returns the keys of a map with string keys, sorted.
+func sortedKeys(rv reflect.Value) []string {
+	keys := make([]string, 0, rv.Len())
+	for _, key := range rv.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,243 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cachesdev/souuup/jsonschema"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+const orderDocument = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"email": {"type": "string", "pattern": "^[^@]+@[^@]+$", "maxLength": 20},
+		"status": {"enum": ["draft", "placed"]},
+		"discount": {"type": "number", "exclusiveMinimum": 0, "maximum": 0.5},
+		"tags": {"type": "array", "items": {"type": "string", "minLength": 2}, "minItems": 1, "uniqueItems": true},
+		"shipping": {"$ref": "#/$defs/address"},
+		"items": {"type": "array", "items": {"$ref": "#/$defs/item"}}
+	},
+	"required": ["id", "email", "shipping"],
+	"$defs": {
+		"address": {
+			"type": "object",
+			"properties": {"city": {"type": "string", "minLength": 2}},
+			"required": ["city"]
+		},
+		"item": {
+			"type": "object",
+			"properties": {"qty": {"type": "integer", "minimum": 1}},
+			"additionalProperties": false
+		}
+	}
+}`
+
+func TestValidator_Validate(t *testing.T) {
+	v, err := jsonschema.Load([]byte(orderDocument))
+	if err != nil {
+		t.Fatalf("unexpected error loading document: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		errorMsg string
+	}{
+		{
+			name: "valid document",
+			data: `{"id": 1, "email": "a@b.c", "status": "draft", "tags": ["go"], "shipping": {"city": "London"},
+				"items": [{"qty": 2}]}`,
+		},
+		{
+			name: "missing required properties",
+			data: `{"id": 1}`,
+			errorMsg: `{"email":{"errors":["value is required"]},` +
+				`"shipping":{"errors":["value is required"]}}`,
+		},
+		{
+			name: "type mismatches",
+			data: `{"id": "1", "email": 3, "shipping": [], "discount": "10%"}`,
+			errorMsg: `{"discount":{"errors":["expected number, got string"]},` +
+				`"email":{"errors":["expected string, got integer"]},` +
+				`"id":{"errors":["expected integer, got string"]},` +
+				`"shipping":{"errors":["expected object, got array"]}}`,
+		},
+		{
+			name: "value keywords",
+			data: `{"id": 0, "email": "not-an-email-address!", "status": "sent", "discount": 0,
+				"tags": ["go", "go", "x"], "shipping": {"city": "L"}}`,
+			errorMsg: `{"discount":{"errors":["value is 0, but needs to be greater than 0"]},` +
+				`"email":{"errors":["length is 21, but needs to be at most 20",` +
+				`"\"not-an-email-address!\" does not match the pattern \"^[^@]+@[^@]+$\""]},` +
+				`"id":{"errors":["value is 0, but needs to be at least 1"]},` +
+				`"shipping":{"city":{"errors":["length is 1, but needs to be at least 2"]}},` +
+				`"status":{"errors":["sent is not one of [draft placed]"]},` +
+				`"tags":{"errors":["elements 0 and 1 are equal, but need to be unique"],` +
+				`"2":{"errors":["length is 1, but needs to be at least 2"]}}}`,
+		},
+		{
+			name: "errors of array elements are keyed by index",
+			data: `{"id": 1, "email": "a@b.c", "shipping": {"city": "London"},
+				"items": [{"qty": 1}, {"qty": 0.5, "sku": "A1"}]}`,
+			errorMsg: `{"items":{"1":{"qty":{"errors":["expected integer, got number"]},` +
+				`"sku":{"errors":["property is not allowed"]}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := v.ValidateJSON([]byte(tt.data))
+
+			// Assert
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.errorMsg {
+				t.Errorf("expected error:\n%s\ngot:\n%v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidator_Combinators(t *testing.T) {
+	v, err := jsonschema.Load([]byte(`{
+		"properties": {
+			"id": {"anyOf": [{"type": "string", "pattern": "^[0-9a-f-]{36}$"}, {"type": "integer"}]},
+			"kind": {"oneOf": [{"const": "a"}, {"type": "string", "maxLength": 1}]},
+			"code": {"not": {"enum": ["test"]}},
+			"roles": {"contains": {"const": "admin"}},
+			"name": {"allOf": [{"minLength": 2}, {"maxLength": 3}]},
+			"flag": true,
+			"never": false
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error loading document: %v", err)
+	}
+
+	// Act
+	err = v.Validate(map[string]any{
+		"id":    "123",
+		"kind":  "a",
+		"code":  "test",
+		"roles": []any{"user"},
+		"name":  "abcd",
+		"flag":  42,
+		"never": 1,
+	})
+
+	// Assert
+	expected := `{"code":{"errors":["value matches a schema it must not match"]},` +
+		`"id":{"errors":["value does not match any of the 2 schemas"]},` +
+		`"kind":{"errors":["value matches 2 of the 2 schemas, but needs to match exactly one"]},` +
+		`"name":{"errors":["length is 4, but needs to be at most 3"]},` +
+		`"never":{"errors":["value matches a schema it must not match"]},` +
+		`"roles":{"errors":["no element matches the required schema"]}}`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+func TestValidator_Bind(t *testing.T) {
+	t.Run("mixes imported and hand-written schemas", func(t *testing.T) {
+		// Arrange
+		v, err := jsonschema.Load([]byte(`{"type": "object", "properties": {"city": {"minLength": 2}}}`))
+		if err != nil {
+			t.Fatalf("unexpected error loading document: %v", err)
+		}
+		schema := u.Schema{
+			"name":    u.Field("jo", r.MinS(3)),
+			"address": v.Bind(map[string]any{"city": "L"}),
+		}
+
+		// Act
+		err = u.NewSouuup(schema).Validate()
+
+		// Assert
+		expected := `{"address":{"city":{"errors":["length is 1, but needs to be at least 2"]}},` +
+			`"name":{"errors":["length is 2, but needs to be at least 3"]}}`
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error:\n%s\ngot:\n%v", expected, err)
+		}
+
+		var ve *u.ValidationError
+		if !errors.As(err, &ve) || ve.Flatten(u.JSONPointer)[0].Path != "/address/city" {
+			t.Errorf("expected the first error at /address/city, got %v", ve.Flatten(u.JSONPointer))
+		}
+	})
+
+	t.Run("round-trips exported schemas", func(t *testing.T) {
+		// Arrange
+		doc := jsonschema.Export(u.Schema{
+			"username": u.Field("", r.NotZero, r.MinS(3)),
+			"tags":     u.Field([]string{}, r.MaxLen[string](1), r.Every(r.MinS(2))),
+		})
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		v, err := jsonschema.Load(data)
+		if err != nil {
+			t.Fatalf("unexpected error loading document: %v", err)
+		}
+
+		// Act
+		err = v.Validate(map[string]any{"username": "jo", "tags": []any{"a", "bc"}})
+
+		// Assert
+		expected := `{"tags":{"errors":["length is 2, but needs to be at most 1"],` +
+			`"0":{"errors":["length is 1, but needs to be at least 2"]}},` +
+			`"username":{"errors":["length is 2, but needs to be at least 3"]}}`
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error:\n%s\ngot:\n%v", expected, err)
+		}
+	})
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{name: "invalid JSON", document: `{"type": }`},
+		{name: "unsupported keyword", document: `{"properties": {"a": {"prefixItems": []}}}`},
+		{name: "remote reference", document: `{"$ref": "https://example.com/schema.json"}`},
+		{name: "unresolvable reference", document: `{"$ref": "#/$defs/missing"}`},
+		{name: "invalid pattern", document: `{"pattern": "("}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := jsonschema.Load([]byte(tt.document))
+
+			// Assert
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	t.Run("ignores annotations and extension keywords", func(t *testing.T) {
+		_, err := jsonschema.Load([]byte(`{"default": 1, "examples": [1], "x-souuup-rules": []}`))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("reports unsupported keywords with ErrUnsupportedKeyword", func(t *testing.T) {
+		_, err := jsonschema.Load([]byte(`{"dependentRequired": {}}`))
+		if !errors.Is(err, jsonschema.ErrUnsupportedKeyword) {
+			t.Errorf("expected ErrUnsupportedKeyword, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+// Package jsonschema converts between souuup schemas and JSON Schema (draft 2020-12) documents.
+//
+// Export turns a schema into a JSON Schema document, so the contracts published to partners
+// are derived from the same schemas used for validation:
+//
+//	doc := jsonschema.Export(u.Schema{
+//		"username": u.Field(reg.Username, r.NotZero, r.MinS(3), r.MaxS(20)),
+//		"age":      u.Field(reg.Age, r.MinN(18)),
+//	})
+//	out, err := json.MarshalIndent(doc, "", "  ")
+//
+// Load turns a JSON Schema document into a Validator for untyped data, such as decoded JSON,
+// which reports failures through the same ValidationError tree as hand-written schemas:
+//
+//	v, err := jsonschema.Load(contract)
+//	if err != nil {
+//		return err
+//	}
+//	err = v.ValidateJSON(body)
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Draft is the URI of the JSON Schema dialect used by this package.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document, or a subschema of one. Keywords that are not modelled by
+// a field are kept in Extensions.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+
+	Type  Types `json:"type,omitempty"`
+	Enum  []any `json:"enum,omitempty"`
+	Const any   `json:"const,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+	Contains    *Schema `json:"contains,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	Not   *Schema   `json:"not,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+
+	// Extensions holds any other keywords, such as the "x-souuup-rules" extension keyword
+	// describing rules that have no JSON Schema equivalent.
+	Extensions map[string]any `json:"-"`
+}
+
+// schemaFields is Schema without its methods, used to marshal the modelled keywords.
+type schemaFields Schema
+
+// MarshalJSON implements the json.Marshaler interface, writing the extension keywords
+// after the modelled ones, sorted by name.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal((*schemaFields)(s))
+	if err != nil || len(s.Extensions) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(s.Extensions))
+	for key := range s.Extensions {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, key := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(s.Extensions[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valueBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The boolean schemas true and
+// false are decoded as an empty schema, and a schema matching nothing. Keywords that are
+// not modelled by a field are kept in Extensions.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = Schema{}
+		return nil
+	case "false":
+		*s = Schema{Not: &Schema{}}
+		return nil
+	}
+
+	var fields schemaFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	for key, value := range keywords {
+		if _, ok := modelledKeywords[key]; ok {
+			continue
+		}
+		if fields.Extensions == nil {
+			fields.Extensions = make(map[string]any)
+		}
+		var v any
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		fields.Extensions[key] = v
+	}
+
+	*s = Schema(fields)
+	return nil
+}
+
+// modelledKeywords is the set of keywords modelled by the fields of Schema.
+var modelledKeywords = func() map[string]struct{} {
+	keywords := make(map[string]struct{})
+	t := reflect.TypeFor[schemaFields]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keywords[name] = struct{}{}
+		}
+	}
+	return keywords
+}()
+
+// Types is the value of the "type" keyword, which is either a single type or a list of types.
+type Types []string
+
+// MarshalJSON implements the json.Marshaler interface, writing a single type as a string.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a single type or a list of types.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Has reports whether t contains typ.
+func (t Types) Has(typ string) bool {
+	return slices.Contains(t, typ)
+}
+
+// String returns the types separated by "|".
+func (t Types) String() string {
+	return strings.Join(t, "|")
+}
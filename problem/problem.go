@@ -0,0 +1,216 @@
+// Package problem renders validation errors as problem details documents (RFC 9457, formerly
+// RFC 7807), the standard JSON body of HTTP API errors.
+//
+// Every failure of a *u.ValidationError is listed in the invalid-params extension member, with
+// the JSON Pointer of its field, its code and its message:
+//
+//	if err := s.Validate(u.WithLocale("es")); err != nil {
+//		_ = problem.Write(w, err)
+//		return
+//	}
+//	// HTTP/1.1 400 Bad Request
+//	// Content-Type: application/problem+json
+//	//
+//	// {
+//	//   "type": "about:blank",
+//	//   "title": "Bad Request",
+//	//   "status": 400,
+//	//   "detail": "validation failed with 1 error",
+//	//   "invalid-params": [
+//	//     {"path": "/username", "code": "string.min_length", "params": {...}, "message": "..."}
+//	//   ]
+//	// }
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+// ContentType is the media type of problem details documents.
+const ContentType = "application/problem+json"
+
+// DefaultType is the problem type of documents without a type, whose title is the status text
+// of their status code.
+const DefaultType = "about:blank"
+
+// Details is a problem details document.
+type Details struct {
+	// Type is a URI reference identifying the problem type.
+	Type string `json:"type,omitempty"`
+
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+
+	// Detail is a human-readable explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// InvalidParams lists the failures of the validation, in the order of the fields.
+	InvalidParams []u.FlatError `json:"invalid-params,omitempty"`
+}
+
+// Option configures the document returned by New.
+type Option func(*options)
+
+// options holds the configuration of a document.
+type options struct {
+	typ      string
+	title    string
+	status   int
+	detail   string
+	instance string
+	style    u.PathStyle
+}
+
+// WithType sets the problem type of the document. It defaults to DefaultType.
+//
+// Example:
+//
+//	problem.Write(w, err, problem.WithType("https://example.com/problems/validation"), problem.WithTitle("Invalid order"))
+func WithType(uri string) Option {
+	return func(o *options) {
+		o.typ = uri
+	}
+}
+
+// WithTitle sets the title of the document. It defaults to the status text of the status code.
+func WithTitle(title string) Option {
+	return func(o *options) {
+		o.title = title
+	}
+}
+
+// WithStatus sets the status code of validation failures. It defaults to http.StatusBadRequest.
+//
+// Example:
+//
+//	problem.Write(w, err, problem.WithStatus(http.StatusUnprocessableEntity))
+func WithStatus(status int) Option {
+	return func(o *options) {
+		o.status = status
+	}
+}
+
+// WithDetail sets the detail of the document, such as a translated explanation. It defaults to
+// the number of failures of the validation.
+func WithDetail(detail string) Option {
+	return func(o *options) {
+		o.detail = detail
+	}
+}
+
+// WithInstance sets the instance of the document, such as the path of the request.
+//
+// Example:
+//
+//	problem.Write(w, err, problem.WithInstance(req.URL.Path))
+func WithInstance(uri string) Option {
+	return func(o *options) {
+		o.instance = uri
+	}
+}
+
+// WithPathStyle sets the style of the paths of the invalid params. It defaults to u.JSONPointer.
+func WithPathStyle(style u.PathStyle) Option {
+	return func(o *options) {
+		o.style = style
+	}
+}
+
+// New returns the problem details document of an error returned by a validation. Failures of a
+// *u.ValidationError are listed in InvalidParams, with the status code set by WithStatus.
+// Payloads rejected by u.ParseJSON with u.ErrInvalidJSON are reported with
+// http.StatusBadRequest and the decoding error as their detail. Validation runs cancelled with
+// u.ErrValidationCancelled are reported with http.StatusServiceUnavailable, and other errors with
+// http.StatusInternalServerError. The messages of other errors are not included, as they may
+// expose internal details. New returns nil for a nil err, so the result of a successful
+// validation can be passed as is.
+//
+// Example:
+//
+//	doc := problem.New(err, problem.WithInstance(req.URL.Path))
+//	log.Printf("rejected %s: %d invalid params", doc.Instance, len(doc.InvalidParams))
+func New(err error, opts ...Option) *Details {
+	if err == nil {
+		return nil
+	}
+
+	o := options{status: http.StatusBadRequest, style: u.JSONPointer}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	d := &Details{Type: o.typ, Title: o.title, Status: o.status, Detail: o.detail, Instance: o.instance}
+
+	var ve *u.ValidationError
+	switch {
+	case errors.As(err, &ve):
+		d.InvalidParams = ve.Flatten(o.style)
+		if d.Detail == "" {
+			d.Detail = failures(len(d.InvalidParams))
+		}
+	case errors.Is(err, u.ErrInvalidJSON):
+		d.Status = http.StatusBadRequest
+		if d.Detail == "" {
+			d.Detail = err.Error()
+		}
+	case errors.Is(err, u.ErrValidationCancelled):
+		d.Status = http.StatusServiceUnavailable
+	default:
+		d.Status = http.StatusInternalServerError
+	}
+
+	if d.Type == "" {
+		d.Type = DefaultType
+	}
+	if d.Title == "" {
+		d.Title = http.StatusText(d.Status)
+	}
+	return d
+}
+
+// Write writes the problem details document of err to w, see New. It returns the error of the
+// encoding, if any. Nothing is written for a nil err.
+//
+// Example:
+//
+//	if err := s.Validate(); err != nil {
+//		_ = problem.Write(w, err)
+//		return
+//	}
+func Write(w http.ResponseWriter, err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+	return New(err, opts...).Write(w)
+}
+
+// Write writes the document to w, with its status code and the ContentType header. It returns
+// the error of the encoding, if any.
+func (d *Details) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+
+	if err := json.NewEncoder(w).Encode(d); err != nil {
+		return fmt.Errorf("encoding problem details: %w", err)
+	}
+	return nil
+}
+
+// failures returns the default detail of a validation with n failures.
+func failures(n int) string {
+	if n == 1 {
+		return "validation failed with 1 error"
+	}
+	return fmt.Sprintf("validation failed with %d errors", n)
+}
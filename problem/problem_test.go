@@ -0,0 +1,154 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachesdev/souuup/problem"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+func validate(opts ...u.Option) error {
+	return u.NewSouuup(u.Schema{
+		"username": u.Field("jo", r.MinS(3)),
+		"address": u.Schema{
+			"city": u.Field("", r.NotZero),
+		},
+	}).Validate(opts...)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		opts     []problem.Option
+		expected string
+	}{
+		{
+			name:     "validation errors",
+			err:      validate(),
+			expected: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed with 2 errors","invalid-params":[{"path":"/address/city","code":"value.not_zero","message":"value is required but has zero value"},{"path":"/username","code":"string.min_length","params":{"actual":2,"min":3},"message":"length is 2, but needs to be at least 3"}]}`,
+		},
+		{
+			name: "options",
+			err:  validate(u.WithFailFast(), u.WithLocale("es")),
+			opts: []problem.Option{
+				problem.WithType("https://example.com/problems/validation"),
+				problem.WithTitle("Invalid user"),
+				problem.WithStatus(http.StatusUnprocessableEntity),
+				problem.WithDetail("el usuario no es válido"),
+				problem.WithInstance("/users"),
+				problem.WithPathStyle(u.DottedPath),
+			},
+			expected: `{"type":"https://example.com/problems/validation","title":"Invalid user","status":422,"detail":"el usuario no es válido","instance":"/users","invalid-params":[{"path":"address.city","code":"value.not_zero","message":"el valor es obligatorio, pero está vacío"}]}`,
+		},
+		{
+			name:     "default title of the status",
+			err:      validate(u.WithFailFast()),
+			opts:     []problem.Option{problem.WithStatus(http.StatusUnprocessableEntity)},
+			expected: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"validation failed with 1 error","invalid-params":[{"path":"/address/city","code":"value.not_zero","message":"value is required but has zero value"}]}`,
+		},
+		{
+			name:     "cancelled validation",
+			err:      cancelledValidation(),
+			expected: `{"type":"about:blank","title":"Service Unavailable","status":503}`,
+		},
+		{
+			name:     "invalid JSON",
+			err:      invalidJSON(),
+			opts:     []problem.Option{problem.WithStatus(http.StatusUnprocessableEntity)},
+			expected: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid JSON: unexpected EOF"}`,
+		},
+		{
+			name:     "other errors",
+			err:      errors.New("connection refused"),
+			expected: `{"type":"about:blank","title":"Internal Server Error","status":500}`,
+		},
+		{
+			name:     "successful validation",
+			err:      u.NewSouuup(u.Schema{"username": u.Field("john", r.MinS(3))}).Validate(),
+			expected: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			doc := problem.New(tt.err, tt.opts...)
+
+			// Assert
+			got, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	// Arrange
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := problem.Write(rec, validate(), problem.WithInstance("/users"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != problem.ContentType {
+		t.Errorf("expected content type %q, got %q", problem.ContentType, got)
+	}
+
+	var doc problem.Details
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatalf("unexpected error decoding the body: %v", err)
+	}
+	if doc.Instance != "/users" || len(doc.InvalidParams) != 2 || doc.InvalidParams[1].Path != "/username" {
+		t.Errorf("expected the problem details of the validation, got %+v", doc)
+	}
+}
+
+func TestWrite_NilError(t *testing.T) {
+	// Arrange
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := problem.Write(rec, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Errorf("expected nothing to be written, got %q", rec.Body.String())
+	}
+}
+
+// cancelledValidation returns the error of a validation run whose context is cancelled.
+func invalidJSON() error {
+	type user struct {
+		Username string `json:"username"`
+	}
+	_, err := u.ParseJSON([]byte(`{"username":`), func(v user) u.Schema {
+		return u.Schema{"username": u.Field(v.Username, r.MinS(3))}
+	})
+	return err
+}
+
+func cancelledValidation() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return u.NewSouuup(u.Schema{"username": u.Field("jo", r.MinS(3))}).ValidateContext(ctx)
+}
@@ -1,11 +1,23 @@
 package r
 
 import (
-	"fmt"
-
 	"github.com/cachesdev/souuup/u"
 )
 
+// Error codes reported by the comparable rules.
+const (
+	CodeNotZero = "value.not_zero"
+	CodeSameAs  = "value.same_as"
+
+	CodeRequiredIf     = "value.required_if"
+	CodeRequiredUnless = "value.required_unless"
+
+	CodeUnique = "value.taken"
+	CodeExists = "value.not_found"
+)
+
+var _ = u.RegisterDescriptor(NotZero[int], u.NewRuleDescriptor(CodeNotZero, nil))
+
 // NotZero validates that a value is not the zero value for its type.
 // This is useful for required fields.
 //
@@ -19,7 +31,7 @@ import (
 func NotZero[T comparable](fs u.FieldState[T]) error {
 	var zero T
 	if fs.Value == zero {
-		return fmt.Errorf("value is required but has zero value")
+		return u.NewRuleError(CodeNotZero, nil)
 	}
 	return nil
 }
@@ -32,10 +44,84 @@ func NotZero[T comparable](fs u.FieldState[T]) error {
 //	// Validate that passwords match
 //	passwordField := u.Field(password, r.SameAs(confirmPassword))
 func SameAs[T comparable](other T) u.Rule[T] {
-	return func(fs u.FieldState[T]) error {
-		if fs.Value != other {
-			return fmt.Errorf("%v does not match %v", fs.Value, other)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeSameAs, u.Params{"other": other}),
+		func(fs u.FieldState[T]) error {
+			if fs.Value != other {
+				return u.NewRuleError(CodeSameAs, u.Params{"other": other, "actual": fs.Value})
+			}
+			return nil
+		})
+}
+
+// RequiredIf validates that a value is not the zero value for its type if cond is true.
+// It reports the same failure as NotZero.
+//
+// Example:
+//
+//	// The card number is required when paying by card
+//	cardField := u.Field(payment.CardNumber, r.RequiredIf[string](payment.Method == "card"))
+func RequiredIf[T comparable](cond bool) u.Rule[T] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeRequiredIf, u.Params{"condition": cond}),
+		func(fs u.FieldState[T]) error {
+			if !cond {
+				return nil
+			}
+			return NotZero(fs)
+		})
+}
+
+// RequiredUnless validates that a value is not the zero value for its type if cond is false.
+// It reports the same failure as NotZero.
+//
+// Example:
+//
+//	// The shipping street is required unless the order is picked up
+//	streetField := u.Field(order.Street, r.RequiredUnless[string](order.Pickup))
+func RequiredUnless[T comparable](cond bool) u.Rule[T] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeRequiredUnless, u.Params{"condition": cond}),
+		func(fs u.FieldState[T]) error {
+			if cond {
+				return nil
+			}
+			return NotZero(fs)
+		})
+}
+
+// Unique validates that a value is not found by the loader of a batch, such as a username that
+// is not taken yet. The value is looked up with the other values of the batch once the schema is
+// validated, see u.Lookup.
+//
+// Example:
+//
+//	var usernames = u.NewBatch("usernames", func(ctx context.Context, names []string) (map[string]struct{}, error) {
+//		return db.ExistingUsernames(ctx, names)
+//	})
+//
+//	usernameField := u.Field(user.Name, r.MinS(3), r.Unique(usernames))
+func Unique[K comparable, V any](b *u.Batch[K, V]) u.Rule[K] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeUnique, u.Params{"batch": b.String()}),
+		u.Lookup(b, func(fs u.FieldState[K], _ V, found bool) error {
+			if found {
+				return u.NewRuleError(CodeUnique, u.Params{"actual": fs.Value})
+			}
+			return nil
+		}))
+}
+
+// Exists validates that a value is found by the loader of a batch, such as the SKU of an
+// existing product. The value is looked up with the other values of the batch once the schema
+// is validated, see u.Lookup.
+//
+// Example:
+//
+//	// A single query for every SKU of the order
+//	skusField := u.Field(order.SKUs, r.Every(r.Exists(products)))
+func Exists[K comparable, V any](b *u.Batch[K, V]) u.Rule[K] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeExists, u.Params{"batch": b.String()}),
+		u.Lookup(b, func(fs u.FieldState[K], _ V, found bool) error {
+			if !found {
+				return u.NewRuleError(CodeExists, u.Params{"actual": fs.Value})
+			}
+			return nil
+		}))
 }
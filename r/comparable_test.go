@@ -1,6 +1,7 @@
 package r_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cachesdev/souuup/internal/testutil"
@@ -156,3 +157,151 @@ func TestSameAs(t *testing.T) {
 		})
 	}
 }
+
+func TestSameAs_Descriptor(t *testing.T) {
+	// Act
+	desc := u.DescriptorOf(r.SameAs("secret"))
+
+	// Assert
+	if desc.Name != r.CodeSameAs || desc.Params["other"] != "secret" {
+		t.Errorf("expected the %s descriptor, got %v", r.CodeSameAs, desc)
+	}
+	if expected := "must match secret"; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
+
+func TestRequiredIf(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    u.Rule[string]
+		value   string
+		wantErr bool
+	}{
+		{name: "RequiredIf: condition met, zero value", rule: r.RequiredIf[string](true), value: "", wantErr: true},
+		{name: "RequiredIf: condition met, non-zero value", rule: r.RequiredIf[string](true), value: "card", wantErr: false},
+		{name: "RequiredIf: condition not met, zero value", rule: r.RequiredIf[string](false), value: "", wantErr: false},
+		{name: "RequiredUnless: condition met, zero value", rule: r.RequiredUnless[string](true), value: "", wantErr: false},
+		{name: "RequiredUnless: condition not met, zero value", rule: r.RequiredUnless[string](false), value: "", wantErr: true},
+		{name: "RequiredUnless: condition not met, non-zero value", rule: r.RequiredUnless[string](false), value: "card", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			field := u.Field(tt.value, tt.rule)
+			s := u.NewSouuup(u.Schema{"field": field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, `{"field":{"errors":["value is required but has zero value"]}}`)
+		})
+	}
+}
+
+func TestLookups(t *testing.T) {
+	var calls int
+	usernames := u.NewBatch("usernames", func(_ context.Context, names []string) (map[string]bool, error) {
+		calls++
+		taken := make(map[string]bool)
+		for _, name := range names {
+			if name == "admin" || name == "root" {
+				taken[name] = true
+			}
+		}
+		return taken, nil
+	})
+
+	tests := []struct {
+		name     string
+		rule     u.Rule[string]
+		values   []string
+		opts     []u.Option
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:   "Unique: unused values",
+			rule:   r.Unique(usernames),
+			values: []string{"john", "jane"},
+		},
+		{
+			name:     "Unique: taken values",
+			rule:     r.Unique(usernames),
+			values:   []string{"john", "admin", "root"},
+			wantErr:  true,
+			errorMsg: `{"names":{"1":{"errors":["admin is already taken"]},"2":{"errors":["root is already taken"]}}}`,
+		},
+		{
+			name:   "Exists: existing values",
+			rule:   r.Exists(usernames),
+			values: []string{"root", "admin"},
+		},
+		{
+			name:     "Exists: missing values",
+			rule:     r.Exists(usernames),
+			values:   []string{"root", "john"},
+			wantErr:  true,
+			errorMsg: `{"names":{"1":{"errors":["john does not exist"]}}}`,
+		},
+		{
+			name:     "Exists: translated",
+			rule:     r.Exists(usernames),
+			values:   []string{"john"},
+			opts:     []u.Option{u.WithLocale("es")},
+			wantErr:  true,
+			errorMsg: `{"names":{"0":{"errors":["john no existe"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			calls = 0
+			s := u.NewSouuup(u.Schema{"names": u.Field(tt.values, r.Every(tt.rule))})
+
+			// Act
+			err := s.Validate(tt.opts...)
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.errorMsg)
+			if calls != 1 {
+				t.Errorf("expected a single call to the loader, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestLookups_Descriptor(t *testing.T) {
+	products := u.NewBatch("products", func(context.Context, []string) (map[string]struct{}, error) {
+		return nil, nil
+	})
+
+	tests := []struct {
+		name     string
+		rule     u.Rule[string]
+		code     string
+		expected string
+	}{
+		{name: "Unique", rule: r.Unique(products), code: r.CodeUnique, expected: "must not exist in products"},
+		{name: "Exists", rule: r.Exists(products), code: r.CodeExists, expected: "must exist in products"},
+		{name: "Lookup", rule: u.Lookup(products, nil), code: u.CodeLookup, expected: "is looked up in products"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			desc := u.DescriptorOf(tt.rule)
+
+			// Assert
+			if desc.Name != tt.code {
+				t.Errorf("expected name %q, got %q", tt.code, desc.Name)
+			}
+			if desc.Description != tt.expected {
+				t.Errorf("expected description %q, got %q", tt.expected, desc.Description)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package r
+
+import (
+	"github.com/cachesdev/souuup/u"
+)
+
+// Error codes reported by the logical rules.
+const (
+	CodeAnd   = "logic.and"
+	CodeOr    = "logic.or"
+	CodeNot   = "logic.not"
+	CodeXor   = "logic.xor"
+	CodeOneOf = "logic.one_of"
+)
+
+// And validates that a value satisfies every one of the given rules. It returns a single error
+// listing every rule that failed, or only the first one when the validation run stops at the
+// first failure.
+//
+// Example:
+//
+//	// Validate that a code is 6 characters long and starts with a prefix
+//	codeField := u.Field(code, r.And(r.LenS(6), HasPrefix("AB")))
+func And[T any](rules ...u.Rule[T]) u.Rule[T] {
+	descs := describeAll(rules)
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeAnd, u.Params{"rules": descs}),
+		func(fs u.FieldState[T]) error {
+			branches := evaluate(fs, rules, descs, stopWhen(fs, failed))
+			if failures := failedBranches(branches); len(failures) > 0 {
+				return u.NewRuleError(CodeAnd, u.Params{
+					"count":  len(rules),
+					"failed": len(failures),
+					"errors": failures,
+				})
+			}
+			return nil
+		})
+}
+
+// Or validates that a value satisfies at least one of the given rules. Rules are applied in
+// order until one of them passes. If all of them fail, it returns a single error listing the
+// failure of every rule.
+//
+// Example:
+//
+//	// Validate that an ID is either a UUID or a legacy numeric ID
+//	idField := u.Field(id, r.Or(IsUUID, IsNumeric))
+func Or[T any](rules ...u.Rule[T]) u.Rule[T] {
+	descs := describeAll(rules)
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeOr, u.Params{"rules": descs}),
+		func(fs u.FieldState[T]) error {
+			branches := evaluate(fs, rules, descs, passed)
+			if len(passedBranches(branches)) > 0 {
+				return nil
+			}
+			return u.NewRuleError(CodeOr, u.Params{
+				"count":  len(rules),
+				"errors": failedBranches(branches),
+			})
+		})
+}
+
+// Not validates that a value does not satisfy the given rule.
+//
+// Example:
+//
+//	// Validate that a username is not an email address
+//	usernameField := u.Field(username, r.Not(ValidEmail))
+func Not[T any](rule u.Rule[T]) u.Rule[T] {
+	desc := u.DescriptorOf(rule)
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeNot, u.Params{"rule": desc}),
+		func(fs u.FieldState[T]) error {
+			if rule(fs) == nil {
+				return u.NewRuleError(CodeNot, u.Params{"rule": desc})
+			}
+			return nil
+		})
+}
+
+// Xor validates that a value satisfies exactly one of two rules.
+//
+// Example:
+//
+//	// Validate that a contact is either a phone number or an email address, but not both
+//	contactField := u.Field(contact, r.Xor(ValidPhone, ValidEmail))
+func Xor[T any](a, b u.Rule[T]) u.Rule[T] {
+	return exactlyOne(CodeXor, []u.Rule[T]{a, b})
+}
+
+// OneOf validates that a value satisfies exactly one of the given rules. It returns an error
+// listing the failure of every rule if none of them pass, or the rules that passed if more
+// than one of them do.
+//
+// Example:
+//
+//	// Validate that a discount is exactly one of the supported kinds
+//	discountField := u.Field(discount, r.OneOf(IsPercentage, IsFixedAmount, IsFreeShipping))
+func OneOf[T any](rules ...u.Rule[T]) u.Rule[T] {
+	return exactlyOne(CodeOneOf, rules)
+}
+
+// exactlyOne returns a rule reporting code unless exactly one of the rules passes.
+func exactlyOne[T any](code string, rules []u.Rule[T]) u.Rule[T] {
+	descs := describeAll(rules)
+	return u.WithDescriptor(u.NewRuleDescriptor(code, u.Params{"rules": descs}),
+		func(fs u.FieldState[T]) error {
+			branches := evaluate(fs, rules, descs, never)
+			matched := passedBranches(branches)
+			if len(matched) == 1 {
+				return nil
+			}
+
+			params := u.Params{"count": len(rules), "passed": matched}
+			if len(matched) == 0 {
+				params["errors"] = failedBranches(branches)
+			}
+			return u.NewRuleError(code, params)
+		})
+}
+
+// branch is the result of applying one of the rules combined by a logical rule.
+type branch struct {
+	index int
+	rule  string
+	err   error
+}
+
+// evaluate applies rules in order to a field state until stop reports true for the result of a
+// rule, or the context of the validation run is cancelled.
+func evaluate[T any](fs u.FieldState[T], rules []u.Rule[T], descs []u.RuleDescriptor, stop func(error) bool) []branch {
+	branches := make([]branch, 0, len(rules))
+	for i, rule := range rules {
+		if fs.Context().Err() != nil {
+			break
+		}
+
+		err := rule(fs)
+		branches = append(branches, branch{index: i, rule: descs[i].Name, err: err})
+		if stop(err) {
+			break
+		}
+	}
+	return branches
+}
+
+// failedBranches returns the failures of the branches that failed.
+func failedBranches(branches []branch) []u.BranchError {
+	var failures []u.BranchError
+	for _, b := range branches {
+		if b.err != nil {
+			failures = append(failures, u.BranchError{Index: b.index, Rule: b.rule, Error: u.AsRuleError(b.err)})
+		}
+	}
+	return failures
+}
+
+// passedBranches returns the indices of the branches that passed.
+func passedBranches(branches []branch) []int {
+	var indices []int
+	for _, b := range branches {
+		if b.err == nil {
+			indices = append(indices, b.index)
+		}
+	}
+	return indices
+}
+
+// describeAll returns the descriptors of rules.
+func describeAll[T any](rules []u.Rule[T]) []u.RuleDescriptor {
+	descs := make([]u.RuleDescriptor, len(rules))
+	for i, rule := range rules {
+		descs[i] = u.DescriptorOf(rule)
+	}
+	return descs
+}
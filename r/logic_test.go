@@ -0,0 +1,159 @@
+package r_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/internal/testutil"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestLogicalRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     u.Rule[string]
+		value    string
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "And: all rules pass",
+			rule:    r.And(r.MinS(2), r.MaxS(4)),
+			value:   "abc",
+			wantErr: false,
+		},
+		{
+			name:     "And: some rules fail",
+			rule:     r.And(r.MinS(2), r.MaxS(4), r.ContainsS("x")),
+			value:    "abcdef",
+			wantErr:  true,
+			errorMsg: "2 of 3 rules failed\n  [1]: length is 6, but needs to be at most 4\n  [2]: \"abcdef\" does not contain \"x\", but needs to",
+		},
+		{
+			name:    "Or: one rule passes",
+			rule:    r.Or(r.LenS(36), r.MaxS(10)),
+			value:   "12345",
+			wantErr: false,
+		},
+		{
+			name:     "Or: all rules fail",
+			rule:     r.Or(r.LenS(3), r.LenS(5)),
+			value:    "ab",
+			wantErr:  true,
+			errorMsg: "value does not satisfy any of the 2 rules\n  [0]: length is 2, but needs to be exactly 3\n  [1]: length is 2, but needs to be exactly 5",
+		},
+		{
+			name:    "Not: rule fails",
+			rule:    r.Not(r.ContainsS("@")),
+			value:   "john",
+			wantErr: false,
+		},
+		{
+			name:     "Not: rule passes",
+			rule:     r.Not(r.ContainsS("@")),
+			value:    "john@example.com",
+			wantErr:  true,
+			errorMsg: `value must not satisfy the rule: must contain "@"`,
+		},
+		{
+			name:    "Xor: exactly one rule passes",
+			rule:    r.Xor(r.ContainsS("@"), r.ContainsS("+")),
+			value:   "john@example.com",
+			wantErr: false,
+		},
+		{
+			name:     "Xor: both rules pass",
+			rule:     r.Xor(r.ContainsS("@"), r.ContainsS("+")),
+			value:    "john+1@example.com",
+			wantErr:  true,
+			errorMsg: "value satisfies 2 of the 2 rules, but needs to satisfy exactly one",
+		},
+		{
+			name:     "OneOf: no rule passes",
+			rule:     r.OneOf(r.InS([]string{"a"}), r.InS([]string{"b"})),
+			value:    "c",
+			wantErr:  true,
+			errorMsg: "value satisfies 0 of the 2 rules, but needs to satisfy exactly one\n  [0]: \"c\" is not in [a], but should be\n  [1]: \"c\" is not in [b], but should be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			fs := u.FieldState[string]{Value: tt.value}
+
+			// Act
+			err := tt.rule(fs)
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.errorMsg)
+		})
+	}
+}
+
+func TestAnd_ShortCircuit(t *testing.T) {
+	// Arrange
+	calls := 0
+	failing := func(u.FieldState[string]) error {
+		calls++
+		return errors.New("failed")
+	}
+	s := u.NewSouuup(u.Schema{"field": u.Field("value", r.And(failing, failing))})
+
+	// Act
+	_ = s.Validate(u.WithBail())
+
+	// Assert
+	if calls != 1 {
+		t.Errorf("expected And to stop at the first failure, got %d calls", calls)
+	}
+}
+
+func TestOr_RuleError(t *testing.T) {
+	// Arrange
+	fs := u.FieldState[string]{Value: "ab"}
+
+	// Act
+	err := r.Or(r.LenS(3), r.MinS(5))(fs)
+
+	// Assert
+	testutil.CheckRuleError(t, err, r.CodeOr, u.Params{
+		"count": 2,
+		"errors": []u.BranchError{
+			{Index: 0, Rule: r.CodeLenS, Error: u.NewRuleError(r.CodeLenS, u.Params{"length": 3, "actual": 2})},
+			{Index: 1, Rule: r.CodeMinS, Error: u.NewRuleError(r.CodeMinS, u.Params{"min": 5, "actual": 2})},
+		},
+	})
+}
+
+func TestOr_Translated(t *testing.T) {
+	// Arrange
+	s := u.NewSouuup(u.Schema{"code": u.Field("ab", r.Or(r.LenS(3), r.MinS(5)))})
+
+	// Act
+	err := s.Validate(u.WithLocale("es"))
+
+	// Assert
+	testutil.CheckError(t, err, true, `{"code":{"errors":["el valor no cumple ninguna de las 2 reglas`+
+		`\n  [0]: la longitud es 2, pero debe tener exactamente 3 caracteres`+
+		`\n  [1]: la longitud es 2, pero debe tener al menos 5 caracteres"]}}`)
+}
+
+func TestLogicalRules_Descriptor(t *testing.T) {
+	// Act
+	desc := u.DescriptorOf(r.Or(r.LenS(36), r.Not(r.ContainsS("-"))))
+
+	// Assert
+	if desc.Name != r.CodeOr {
+		t.Errorf("expected name %q, got %q", r.CodeOr, desc.Name)
+	}
+	rules, ok := desc.Params["rules"].([]u.RuleDescriptor)
+	if !ok || !reflect.DeepEqual([]string{rules[0].Name, rules[1].Name}, []string{r.CodeLenS, r.CodeNot}) {
+		t.Errorf("expected the descriptors of the combined rules, got %v", desc.Params["rules"])
+	}
+	if expected := `any of: must be exactly 36 characters long, must not satisfy: must contain "-"`; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
@@ -0,0 +1,186 @@
+package r
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+// Error codes reported by the map rules.
+const (
+	CodeKeys        = "map.keys"
+	CodeValues      = "map.values"
+	CodeMinKeys     = "map.min_keys"
+	CodeMaxKeys     = "map.max_keys"
+	CodeRequiredKey = "map.required_key"
+	CodeUnknownKey  = "map.unknown_key"
+)
+
+// Keys validates that every key of a map satisfies the given rule. Failures are reported
+// under the offending key, in the nested errors of the field.
+//
+// Example:
+//
+//	// Validate that every label name is at most 63 characters long
+//	labelsField := u.Field(meta.Labels, r.Keys[string, string](r.MaxS(63)))
+//	// {"labels":{"a-very-long-label-name...":{"errors":["length is 70, but needs to be at most 63"]}}}
+func Keys[K comparable, V any](rule u.Rule[K]) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeKeys, u.Params{"rule": u.DescriptorOf(rule)}),
+		func(fs u.FieldState[map[K]V]) error {
+			keys := sortedKeys(fs.Value)
+			results, err := validateElements(fs, keys, keyTag(keys), rule, stopWhen(fs, failed))
+			if err != nil {
+				return err
+			}
+			return keyErrors(keys, results)
+		})
+}
+
+// Values validates that every value of a map satisfies the given rule. Failures are reported
+// under the key of the offending value, in the nested errors of the field.
+//
+// Example:
+//
+//	// Validate that every translation is given
+//	translationsField := u.Field(product.Translations, r.Values[string](r.NotZero[string]))
+//	// {"translations":{"es":{"errors":["value is required but has zero value"]}}}
+func Values[K comparable, V any](rule u.Rule[V]) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeValues, u.Params{"rule": u.DescriptorOf(rule)}),
+		func(fs u.FieldState[map[K]V]) error {
+			keys := sortedKeys(fs.Value)
+			values := make([]V, len(keys))
+			for i, key := range keys {
+				values[i] = fs.Value[key]
+			}
+
+			results, err := validateElements(fs, values, keyTag(keys), rule, stopWhen(fs, failed))
+			if err != nil {
+				return err
+			}
+			return keyErrors(keys, results)
+		})
+}
+
+// MinKeys validates that a map has at least n keys.
+//
+// Example:
+//
+//	// Validate that at least one label is set
+//	labelsField := u.Field(meta.Labels, r.MinKeys[string, string](1))
+func MinKeys[K comparable, V any](n int) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMinKeys, u.Params{"min": n}),
+		func(fs u.FieldState[map[K]V]) error {
+			length := len(fs.Value)
+			if length < n {
+				return u.NewRuleError(CodeMinKeys, u.Params{"min": n, "actual": length})
+			}
+			return nil
+		})
+}
+
+// MaxKeys validates that a map has at most n keys.
+//
+// Example:
+//
+//	// Validate that at most 10 labels are set
+//	labelsField := u.Field(meta.Labels, r.MaxKeys[string, string](10))
+func MaxKeys[K comparable, V any](n int) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMaxKeys, u.Params{"max": n}),
+		func(fs u.FieldState[map[K]V]) error {
+			length := len(fs.Value)
+			if length > n {
+				return u.NewRuleError(CodeMaxKeys, u.Params{"max": n, "actual": length})
+			}
+			return nil
+		})
+}
+
+// RequiredKeys validates that a map has every one of the given keys. Missing keys are reported
+// under the key, in the nested errors of the field.
+//
+// Example:
+//
+//	// Validate that the English translation is always given
+//	translationsField := u.Field(product.Translations, r.RequiredKeys[string, string]("en"))
+//	// {"translations":{"en":{"errors":["key is required"]}}}
+func RequiredKeys[K comparable, V any](keys ...K) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeRequiredKey, u.Params{"keys": keys}),
+		func(fs u.FieldState[map[K]V]) error {
+			errs := u.NewValidationError()
+			for _, key := range keys {
+				if _, ok := fs.Value[key]; !ok {
+					errs.AddError(fmt.Sprint(key), u.NewRuleError(CodeRequiredKey, u.Params{"key": key}))
+					if fs.ShortCircuit() {
+						break
+					}
+				}
+			}
+			return nestedErrors(errs)
+		})
+}
+
+// AllowedKeys validates that a map only has keys within the given set. Unknown keys are reported
+// under the key, in the nested errors of the field.
+//
+// Example:
+//
+//	// Validate that translations are only given for supported locales
+//	translationsField := u.Field(product.Translations, r.AllowedKeys[string, string]("en", "es", "pt"))
+//	// {"translations":{"fr":{"errors":["key is not one of [en es pt]"]}}}
+func AllowedKeys[K comparable, V any](keys ...K) u.MapRule[K, V] {
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeUnknownKey, u.Params{"keys": keys}),
+		func(fs u.FieldState[map[K]V]) error {
+			errs := u.NewValidationError()
+			for _, key := range sortedKeys(fs.Value) {
+				if !slices.Contains(keys, key) {
+					errs.AddError(fmt.Sprint(key), u.NewRuleError(CodeUnknownKey, u.Params{"key": key, "keys": keys}))
+					if fs.ShortCircuit() {
+						break
+					}
+				}
+			}
+			return nestedErrors(errs)
+		})
+}
+
+// sortedKeys returns the keys of a map sorted by their string representation, which is the
+// tag their failures are reported under.
+func sortedKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
+
+// keyTag returns a function returning the tag of the key at index i of keys.
+func keyTag[K comparable](keys []K) func(int) u.FieldTag {
+	return func(i int) u.FieldTag {
+		return fmt.Sprint(keys[i])
+	}
+}
+
+// keyErrors returns the failures of the results of validating the keys, or values, of a map
+// as nested errors keyed by their key.
+func keyErrors[K comparable](keys []K, results []error) error {
+	errs := u.NewValidationError()
+	for i, err := range results {
+		if err != nil {
+			errs.AddError(fmt.Sprint(keys[i]), err)
+		}
+	}
+	return nestedErrors(errs)
+}
+
+// nestedErrors returns errs if it has errors, and nil otherwise.
+func nestedErrors(errs *u.ValidationError) error {
+	if !errs.HasErrors() {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,150 @@
+package r_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cachesdev/souuup/internal/testutil"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestMapRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    map[string]string
+		rule     u.MapRule[string, string]
+		opts     []u.Option
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "Keys: valid keys",
+			value:   map[string]string{"app": "web", "tier": "db"},
+			rule:    r.Keys[string, string](r.MinS(3)),
+			wantErr: false,
+		},
+		{
+			name:     "Keys: invalid keys are reported under the key",
+			value:    map[string]string{"app": "web", "a": "x", "b": "y"},
+			rule:     r.Keys[string, string](r.MinS(3)),
+			wantErr:  true,
+			errorMsg: `{"labels":{"a":{"errors":["length is 1, but needs to be at least 3"]},"b":{"errors":["length is 1, but needs to be at least 3"]}}}`,
+		},
+		{
+			name:     "Values: invalid values are reported under their key",
+			value:    map[string]string{"en": "Hello", "es": ""},
+			rule:     r.Values[string](r.NotZero[string]),
+			wantErr:  true,
+			errorMsg: `{"labels":{"es":{"errors":["value is required but has zero value"]}}}`,
+		},
+		{
+			name:     "Values: stops at the first failure when bailing",
+			value:    map[string]string{"a": "", "b": ""},
+			rule:     r.Values[string](r.NotZero[string]),
+			opts:     []u.Option{u.WithBail()},
+			wantErr:  true,
+			errorMsg: `{"labels":{"a":{"errors":["value is required but has zero value"]}}}`,
+		},
+		{
+			name:    "MinKeys: enough keys",
+			value:   map[string]string{"a": "x"},
+			rule:    r.MinKeys[string, string](1),
+			wantErr: false,
+		},
+		{
+			name:     "MinKeys: too few keys",
+			value:    map[string]string{},
+			rule:     r.MinKeys[string, string](1),
+			wantErr:  true,
+			errorMsg: `{"labels":{"errors":["has 0 keys, but needs at least 1"]}}`,
+		},
+		{
+			name:     "MaxKeys: too many keys",
+			value:    map[string]string{"a": "x", "b": "y"},
+			rule:     r.MaxKeys[string, string](1),
+			wantErr:  true,
+			errorMsg: `{"labels":{"errors":["has 2 keys, but needs at most 1"]}}`,
+		},
+		{
+			name:    "RequiredKeys: all keys present",
+			value:   map[string]string{"en": "Hello", "es": "Hola"},
+			rule:    r.RequiredKeys[string, string]("en"),
+			wantErr: false,
+		},
+		{
+			name:     "RequiredKeys: missing keys are reported under the key",
+			value:    map[string]string{"es": "Hola"},
+			rule:     r.RequiredKeys[string, string]("en", "pt"),
+			wantErr:  true,
+			errorMsg: `{"labels":{"en":{"errors":["key is required"]},"pt":{"errors":["key is required"]}}}`,
+		},
+		{
+			name:    "AllowedKeys: known keys",
+			value:   map[string]string{"en": "Hello"},
+			rule:    r.AllowedKeys[string, string]("en", "es"),
+			wantErr: false,
+		},
+		{
+			name:     "AllowedKeys: unknown keys are reported under the key",
+			value:    map[string]string{"en": "Hello", "fr": "Bonjour"},
+			rule:     r.AllowedKeys[string, string]("en", "es"),
+			wantErr:  true,
+			errorMsg: `{"labels":{"fr":{"errors":["key is not one of [en es]"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			field := u.Field(tt.value, tt.rule)
+			s := u.NewSouuup(u.Schema{"labels": field})
+
+			// Act
+			err := s.Validate(tt.opts...)
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.errorMsg)
+		})
+	}
+}
+
+func TestValues_NestedMaps(t *testing.T) {
+	// Arrange
+	value := map[string]map[string]int{"eu": {"es": 1, "fr": -1}}
+	field := u.Field(value, r.Values[string](r.Values[string](r.MinN(0))))
+	s := u.NewSouuup(u.Schema{"stock": field})
+
+	// Act
+	err := s.Validate()
+
+	// Assert
+	var ve *u.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	flat := ve.Flatten(u.JSONPointer)
+	if len(flat) != 1 || flat[0].Path != "/stock/eu/fr" || flat[0].Code != r.CodeMinN {
+		t.Errorf("expected a single %s error at /stock/eu/fr, got %v", r.CodeMinN, flat)
+	}
+}
+
+func TestValues_ElementState(t *testing.T) {
+	// Arrange
+	var paths []string
+	schema := u.Schema{
+		"translations": u.Field(map[string]string{"es": "hola", "en": "hello"}, r.Values[string](func(fs u.FieldState[string]) error {
+			paths = append(paths, u.JSONPointer.Format(fs.Path()))
+			return nil
+		})),
+	}
+
+	// Act
+	_ = u.NewSouuup(schema).Validate()
+
+	// Assert
+	if expected := []string{"/translations/en", "/translations/es"}; !slices.Equal(paths, expected) {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
@@ -1,11 +1,18 @@
 package r
 
 import (
-	"fmt"
-
 	"github.com/cachesdev/souuup/u"
 )
 
+// Error codes reported by the numeric rules.
+const (
+	CodeMinN = "number.min"
+	CodeMaxN = "number.max"
+	CodeGt   = "number.gt"
+	CodeLt   = "number.lt"
+	CodeNeqN = "number.neq"
+)
+
 // MinN validates if a numeric value is at least n.
 //
 // Example:
@@ -13,12 +20,13 @@ import (
 //	// Validate that age is at least 18
 //	ageField := u.Field(25, r.MinN(18))
 func MinN[T u.Numeric](n T) u.NumericRule[T] {
-	return func(fd u.FieldState[T]) error {
-		if fd.Value < n {
-			return fmt.Errorf("value is %v, but needs to be at least %v", fd.Value, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMinN, u.Params{"min": n}),
+		func(fd u.FieldState[T]) error {
+			if fd.Value < n {
+				return u.NewRuleError(CodeMinN, u.Params{"min": n, "actual": fd.Value})
+			}
+			return nil
+		})
 }
 
 // MaxN validates if a numeric value is at most n.
@@ -28,12 +36,13 @@ func MinN[T u.Numeric](n T) u.NumericRule[T] {
 //	// Validate that age is at most 120
 //	ageField := u.Field(25, r.MaxN(120))
 func MaxN[T u.Numeric](n T) u.NumericRule[T] {
-	return func(fd u.FieldState[T]) error {
-		if fd.Value > n {
-			return fmt.Errorf("value is %v, but needs to be at most %v", fd.Value, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMaxN, u.Params{"max": n}),
+		func(fd u.FieldState[T]) error {
+			if fd.Value > n {
+				return u.NewRuleError(CodeMaxN, u.Params{"max": n, "actual": fd.Value})
+			}
+			return nil
+		})
 }
 
 // Gt validates if a numeric value is greater than n.
@@ -43,12 +52,13 @@ func MaxN[T u.Numeric](n T) u.NumericRule[T] {
 //	// Validate that age is greater than 18
 //	ageField := u.Field(25, r.Gt(18))
 func Gt[T u.Numeric](n T) u.NumericRule[T] {
-	return func(fd u.FieldState[T]) error {
-		if fd.Value <= n {
-			return fmt.Errorf("value is %v, but needs to be greater than %v", fd.Value, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeGt, u.Params{"limit": n}),
+		func(fd u.FieldState[T]) error {
+			if fd.Value <= n {
+				return u.NewRuleError(CodeGt, u.Params{"limit": n, "actual": fd.Value})
+			}
+			return nil
+		})
 }
 
 // Gte validates if a numeric value greater than or equal to n. It is an alias for MinN.
@@ -68,12 +78,13 @@ func Gte[T u.Numeric](n T) u.NumericRule[T] {
 //	// Validate that age is less than 120
 //	ageField := u.Field(25, r.Lt(120))
 func Lt[T u.Numeric](n T) u.NumericRule[T] {
-	return func(fd u.FieldState[T]) error {
-		if fd.Value >= n {
-			return fmt.Errorf("value is %v, but needs to be less than %v", fd.Value, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeLt, u.Params{"limit": n}),
+		func(fd u.FieldState[T]) error {
+			if fd.Value >= n {
+				return u.NewRuleError(CodeLt, u.Params{"limit": n, "actual": fd.Value})
+			}
+			return nil
+		})
 }
 
 // Lte validates if a numeric value less than or equal to n. It is an alias for MaxN.
@@ -93,10 +104,11 @@ func Lte[T u.Numeric](n T) u.NumericRule[T] {
 //	// Validate that multi-buy quantity is not 1
 //	cartSizeField := u.Field(5, r.NeqN(1))
 func NeqN[T u.Numeric](n T) u.NumericRule[T] {
-	return func(fd u.FieldState[T]) error {
-		if fd.Value == n {
-			return fmt.Errorf("value is %v, but needs to not equal to %v", fd.Value, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeNeqN, u.Params{"value": n}),
+		func(fd u.FieldState[T]) error {
+			if fd.Value == n {
+				return u.NewRuleError(CodeNeqN, u.Params{"value": n, "actual": fd.Value})
+			}
+			return nil
+		})
 }
@@ -0,0 +1,47 @@
+package r
+
+import (
+	"github.com/cachesdev/souuup/u"
+)
+
+// Error codes reported by the pointer rules.
+const (
+	CodeRequired = "value.required"
+	CodeNull     = "value.null"
+)
+
+var (
+	_ = u.RegisterDescriptor(Required[int], u.NewRuleDescriptor(CodeRequired, nil))
+	_ = u.RegisterDescriptor(Null[int], u.NewRuleDescriptor(CodeNull, nil))
+)
+
+// Required validates that a pointer is not nil. Unlike NotZero on the value pointed to,
+// it accepts pointers to zero values, such as a pointer to an empty string or to false.
+//
+// Example:
+//
+//	// Validate that the terms were answered, whether accepted or not
+//	termsField := u.Field(form.AcceptTerms, r.Required[bool])
+//
+//	// Validate that a nickname is given, and at least 3 characters long
+//	nicknameField := u.Field(user.Nickname, r.Required[string], u.Ptr(r.MinS(3)))
+func Required[T any](fs u.FieldState[*T]) error {
+	if fs.Value == nil {
+		return u.NewRuleError(CodeRequired, nil)
+	}
+	return nil
+}
+
+// Null validates that a pointer is nil. This is useful for fields that must not be set
+// in some states, such as a cancellation date on an active subscription.
+//
+// Example:
+//
+//	// Validate that an active subscription has no cancellation date
+//	cancelledField := u.Field(sub.CancelledAt, u.When(sub.Active, r.Null[time.Time]))
+func Null[T any](fs u.FieldState[*T]) error {
+	if fs.Value != nil {
+		return u.NewRuleError(CodeNull, nil)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package r_test
+
+import (
+	"testing"
+
+	"github.com/cachesdev/souuup/internal/testutil"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestRequired(t *testing.T) {
+	empty, name := "", "john"
+
+	tests := []struct {
+		name     string
+		value    *string
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "non-nil pointer",
+			value:   &name,
+			wantErr: false,
+		},
+		{
+			name:    "pointer to zero value",
+			value:   &empty,
+			wantErr: false,
+		},
+		{
+			name:     "nil pointer",
+			value:    nil,
+			wantErr:  true,
+			errorMsg: `{"field":{"errors":["value is required"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			field := u.Field(tt.value, r.Required[string])
+			s := u.NewSouuup(u.Schema{"field": field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.errorMsg)
+		})
+	}
+}
+
+func TestNull(t *testing.T) {
+	zero := 0
+
+	tests := []struct {
+		name     string
+		value    *int
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "nil pointer",
+			value:   nil,
+			wantErr: false,
+		},
+		{
+			name:     "pointer to zero value",
+			value:    &zero,
+			wantErr:  true,
+			errorMsg: `{"field":{"errors":["value must be null"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			field := u.Field(tt.value, r.Null[int])
+			s := u.NewSouuup(u.Schema{"field": field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.errorMsg)
+		})
+	}
+}
+
+func TestPointers_Descriptor(t *testing.T) {
+	// Act
+	required, null := u.DescriptorOf(r.Required[string]), u.DescriptorOf(r.Null[bool])
+
+	// Assert
+	if required.Name != r.CodeRequired || required.Description != "is required" {
+		t.Errorf("expected the %s descriptor, got %v", r.CodeRequired, required)
+	}
+	if null.Name != r.CodeNull || null.Description != "must be null" {
+		t.Errorf("expected the %s descriptor, got %v", r.CodeNull, null)
+	}
+}
@@ -1,49 +1,63 @@
 package r
 
 import (
-	"fmt"
 	"slices"
-	"strings"
+	"strconv"
+	"sync"
 
 	"github.com/cachesdev/souuup/u"
 )
 
+// Error codes reported by the slice rules.
+const (
+	CodeEvery     = "slice.every"
+	CodeSome      = "slice.some"
+	CodeSomeEmpty = "slice.some_empty"
+	CodeNone      = "slice.none"
+	CodeMinLen    = "slice.min_length"
+	CodeMaxLen    = "slice.max_length"
+	CodeExactLen  = "slice.length"
+	CodeContains  = "slice.contains"
+)
+
 // Every validates that every element in the slice satisfies the given rule.
-// It returns an error as soon as any element fails the validation.
+// It returns an error listing every element that failed the validation, or only the
+// first one when the validation run stops at the first failure.
 //
 // Example:
 //
 //	// Validate that all interests are at least 3 characters long
 //	interestsField := u.Field(user.Interests, r.Every(r.MinS(3)))
 func Every[T any](rule u.Rule[T]) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		slice := fs.Value
-		if len(slice) == 0 {
-			return nil // Empty slices pass validation by default
-		}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeEvery, u.Params{"rule": u.DescriptorOf(rule)}),
+		func(fs u.FieldState[[]T]) error {
+			slice := fs.Value
+			if len(slice) == 0 {
+				return nil // Empty slices pass validation by default
+			}
 
-		errors := make(map[int]error)
-		for i, item := range slice {
-			itemState := u.FieldState[T]{Value: item}
-			if err := rule(itemState); err != nil {
-				errors[i] = err
+			results, err := validateElements(fs, fs.Value, index, rule, stopWhen(fs, failed))
+			if err != nil {
+				return err
 			}
-		}
 
-		if len(errors) > 0 {
-			var errMsgs []string
-			errMsgs = append(errMsgs, fmt.Sprintf("%d elements failed validation", len(errors)))
-			for i, err := range errors {
-				errMsgs = append(errMsgs, fmt.Sprintf("  [%d]: %s", i, err.Error()))
+			var errors []u.ElementError
+			for i, err := range results {
+				if err != nil {
+					errors = append(errors, u.ElementError{Index: i, Error: u.AsRuleError(err)})
+				}
 			}
-			return fmt.Errorf("%s", strings.Join(errMsgs, "\n"))
-		}
-		return nil
-	}
+
+			if len(errors) > 0 {
+				return u.NewRuleError(CodeEvery, u.Params{"failed": len(errors), "errors": errors})
+			}
+			return nil
+		})
 }
 
 // Some validates that at least one element in the slice satisfies the given rule.
-// It returns an error if all elements fail the validation.
+// It returns an error if all elements fail the validation. When the validation run stops
+// at the first failure, the elements after the first passing one are not validated.
 //
 // Example:
 //
@@ -55,39 +69,40 @@ func Every[T any](rule u.Rule[T]) u.SliceRule[T] {
 //		return nil
 //	}))
 func Some[T any](rule u.Rule[T]) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		slice := fs.Value
-		if len(slice) == 0 {
-			return fmt.Errorf("slice is empty, but needs at least one valid element")
-		}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeSome, u.Params{"rule": u.DescriptorOf(rule)}),
+		func(fs u.FieldState[[]T]) error {
+			slice := fs.Value
+			if len(slice) == 0 {
+				return u.NewRuleError(CodeSomeEmpty, nil)
+			}
 
-		somePassed := false
+			somePassed := false
 
-		errors := make(map[int]error)
-		for i, item := range slice {
-			itemState := u.FieldState[T]{Value: item}
-			if err := rule(itemState); err != nil {
-				errors[i] = err
-			} else {
-				somePassed = true
+			results, err := validateElements(fs, fs.Value, index, rule, stopWhen(fs, passed))
+			if err != nil {
+				return err
 			}
-		}
 
-		if !somePassed {
-			var errMsgs []string
-			errMsgs = append(errMsgs, fmt.Sprintf("all %d elements failed validation", len(errors)))
-			for i, err := range errors {
-				errMsgs = append(errMsgs, fmt.Sprintf("  [%d]: %s", i, err.Error()))
+			var errors []u.ElementError
+			for i, err := range results {
+				if err != nil {
+					errors = append(errors, u.ElementError{Index: i, Error: u.AsRuleError(err)})
+				} else {
+					somePassed = true
+				}
 			}
-			return fmt.Errorf("%s", strings.Join(errMsgs, "\n"))
-		}
 
-		return nil
-	}
+			if !somePassed {
+				return u.NewRuleError(CodeSome, u.Params{"failed": len(errors), "errors": errors})
+			}
+
+			return nil
+		})
 }
 
 // None validates that no element in the slice satisfies the given rule.
-// It returns an error if any element passes the validation.
+// It returns an error if any element passes the validation, reporting only the first one
+// when the validation run stops at the first failure.
 //
 // Example:
 //
@@ -100,33 +115,33 @@ func Some[T any](rule u.Rule[T]) u.SliceRule[T] {
 //		return fmt.Errorf("word is not banned") // If word is not banned, inner rule fails
 //	}))
 func None[T any](rule u.Rule[T]) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		slice := fs.Value
-		if len(slice) == 0 {
-			return nil // Empty slices pass validation by default
-		}
-
-		unexpectedPassIndices := []int{}
-
-		for i, item := range slice {
-			itemState := u.FieldState[T]{Value: item}
-			if rule(itemState) == nil {
-				unexpectedPassIndices = append(unexpectedPassIndices, i)
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeNone, u.Params{"rule": u.DescriptorOf(rule)}),
+		func(fs u.FieldState[[]T]) error {
+			slice := fs.Value
+			if len(slice) == 0 {
+				return nil // Empty slices pass validation by default
 			}
-		}
 
-		if len(unexpectedPassIndices) > 0 {
-			var errMsgs []string
-			errMsgs = append(errMsgs, fmt.Sprintf("%d elements unexpectedly passed validation (expected none to pass rule)", len(unexpectedPassIndices)))
+			results, err := validateElements(fs, fs.Value, index, rule, stopWhen(fs, passed))
+			if err != nil {
+				return err
+			}
 
-			for _, i := range unexpectedPassIndices {
-				errMsgs = append(errMsgs, fmt.Sprintf("  [%d]: failed", i))
+			unexpectedPassIndices := []int{}
+			for i, err := range results {
+				if err == nil {
+					unexpectedPassIndices = append(unexpectedPassIndices, i)
+				}
 			}
 
-			return fmt.Errorf("%s", strings.Join(errMsgs, "\n"))
-		}
-		return nil
-	}
+			if len(unexpectedPassIndices) > 0 {
+				return u.NewRuleError(CodeNone, u.Params{
+					"passed":  len(unexpectedPassIndices),
+					"indices": unexpectedPassIndices,
+				})
+			}
+			return nil
+		})
 }
 
 // MinLen validates that a slice has at least n elements.
@@ -136,13 +151,14 @@ func None[T any](rule u.Rule[T]) u.SliceRule[T] {
 //	// Validate that a user has at least one interest
 //	interestsField := u.Field(user.Interests, r.MinLen(1))
 func MinLen[T any](n int) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		length := len(fs.Value)
-		if length < n {
-			return fmt.Errorf("length is %d, but needs to be at least %d", length, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMinLen, u.Params{"min": n}),
+		func(fs u.FieldState[[]T]) error {
+			length := len(fs.Value)
+			if length < n {
+				return u.NewRuleError(CodeMinLen, u.Params{"min": n, "actual": length})
+			}
+			return nil
+		})
 }
 
 // MaxLen validates that a slice has at most n elements.
@@ -152,13 +168,14 @@ func MinLen[T any](n int) u.SliceRule[T] {
 //	// Validate that a user has at most 5 interests
 //	interestsField := u.Field(user.Interests, r.MaxLen(5))
 func MaxLen[T any](n int) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		length := len(fs.Value)
-		if length > n {
-			return fmt.Errorf("length is %d, but needs to be at most %d", length, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMaxLen, u.Params{"max": n}),
+		func(fs u.FieldState[[]T]) error {
+			length := len(fs.Value)
+			if length > n {
+				return u.NewRuleError(CodeMaxLen, u.Params{"max": n, "actual": length})
+			}
+			return nil
+		})
 }
 
 // ExactLen validates that a slice has exactly n elements.
@@ -168,13 +185,14 @@ func MaxLen[T any](n int) u.SliceRule[T] {
 //	// Validate that a team has exactly 5 members
 //	teamMembersField := u.Field(team.Members, r.ExactLen(5))
 func ExactLen[T any](n int) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		length := len(fs.Value)
-		if length != n {
-			return fmt.Errorf("length is %d, but needs to be exactly %d", length, n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeExactLen, u.Params{"length": n}),
+		func(fs u.FieldState[[]T]) error {
+			length := len(fs.Value)
+			if length != n {
+				return u.NewRuleError(CodeExactLen, u.Params{"length": n, "actual": length})
+			}
+			return nil
+		})
 }
 
 // Contains validates that a slice contains a matching element for comparable slices.
@@ -184,10 +202,77 @@ func ExactLen[T any](n int) u.SliceRule[T] {
 //	// Validate that a team has a goalkeeper
 //	teamMembersField := u.Field(team.Members, r.Contains("GK"))
 func Contains[T comparable](member T) u.SliceRule[T] {
-	return func(fs u.FieldState[[]T]) error {
-		if !slices.Contains(fs.Value, member) {
-			return fmt.Errorf("%v does not contain %v, but needs to", fs.Value, member)
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeContains, u.Params{"member": member}),
+		func(fs u.FieldState[[]T]) error {
+			if !slices.Contains(fs.Value, member) {
+				return u.NewRuleError(CodeContains, u.Params{"member": member, "actual": fs.Value})
+			}
+			return nil
+		})
+}
+
+// validateElements applies rule to every element of elems, the elements of the value of fs, in
+// parallel when the validation run is concurrent, and returns the error of each element by index.
+// Each element is validated with a state nested in fs under the tag returned by tag for its
+// index. If stop reports true for the result of an element, the elements after it are skipped
+// and the returned results end at the first such element, just as in a sequential run. If the
+// context of the run is cancelled, the remaining elements are skipped and the context error is
+// returned.
+func validateElements[P, T any](fs u.FieldState[P], elems []T, tag func(int) u.FieldTag, rule u.Rule[T], stop func(error) bool) ([]error, error) {
+	results := make([]error, len(elems))
+
+	var mu sync.Mutex
+	cutoff := len(elems)
+
+	u.ForEach(fs, len(elems), func(i int) {
+		mu.Lock()
+		skip := i > cutoff
+		mu.Unlock()
+
+		if skip || fs.Context().Err() != nil {
+			return
 		}
-		return nil
+
+		err := rule(u.DeriveAt(fs, tag(i), elems[i]))
+		results[i] = err
+
+		if stop(err) {
+			mu.Lock()
+			cutoff = min(cutoff, i)
+			mu.Unlock()
+		}
+	})
+
+	if err := fs.Context().Err(); err != nil {
+		return nil, err
+	}
+	return results[:min(cutoff+1, len(results))], nil
+}
+
+// index returns the tag of the element of a slice at index i.
+func index(i int) u.FieldTag {
+	return strconv.Itoa(i)
+}
+
+// failed reports whether a rule failed, for short-circuiting on the first failure.
+func failed(err error) bool {
+	return err != nil
+}
+
+// passed reports whether a rule passed, for short-circuiting on the first pass.
+func passed(err error) bool {
+	return err == nil
+}
+
+// never never short-circuits.
+func never(error) bool {
+	return false
+}
+
+// stopWhen returns stop if the validation run of fs short-circuits, and never otherwise.
+func stopWhen[T any](fs u.FieldState[T], stop func(error) bool) func(error) bool {
+	if fs.ShortCircuit() {
+		return stop
 	}
+	return never
 }
@@ -1,7 +1,10 @@
 package r_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -212,7 +215,7 @@ func TestEvery(t *testing.T) {
 			name:     "one element fails",
 			value:    []string{"abc", "de", "fghi"},
 			wantErr:  true,
-			errorMsg: "1 elements failed validation",
+			errorMsg: "1 element failed validation",
 		},
 		{
 			name:     "multiple elements fail",
@@ -321,7 +324,7 @@ func TestNone(t *testing.T) {
 			name:     "one element passes (has negative)",
 			value:    []int{1, -3, 5, 7},
 			wantErr:  true,
-			errorMsg: "1 elements unexpectedly passed validation (expected none to pass rule)",
+			errorMsg: "1 element unexpectedly passed validation (expected none to pass rule)",
 		},
 		{
 			name:     "multiple elements pass (has negatives)",
@@ -354,3 +357,202 @@ func TestNone(t *testing.T) {
 		})
 	}
 }
+
+func TestEvery_RuleError(t *testing.T) {
+	fs := u.FieldState[[]string]{Value: []string{"abc", "d", "ef"}}
+	err := r.Every(r.MinS(3))(fs)
+	testutil.CheckRuleError(t, err, r.CodeEvery, u.Params{
+		"failed": 2,
+		"errors": []u.ElementError{
+			{Index: 1, Error: u.RuleError{
+				Code:    r.CodeMinS,
+				Params:  u.Params{"min": 3, "actual": 1},
+				Message: "length is 1, but needs to be at least 3",
+			}},
+			{Index: 2, Error: u.RuleError{
+				Code:    r.CodeMinS,
+				Params:  u.Params{"min": 3, "actual": 2},
+				Message: "length is 2, but needs to be at least 3",
+			}},
+		},
+	})
+}
+
+func TestEvery_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	rule := func(fs u.FieldState[int]) error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return nil
+	}
+
+	err := u.NewSouuup(u.Schema{
+		"items": u.Field([]int{1, 2, 3, 4}, r.Every(rule)),
+	}).ValidateContext(ctx)
+
+	if !errors.Is(err, u.ErrValidationCancelled) {
+		t.Errorf("expected ErrValidationCancelled, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected Every to stop after cancellation, but rule was called %d times", calls)
+	}
+}
+
+func TestEvery_Concurrency(t *testing.T) {
+	items := make([]int, 5000)
+	for i := range items {
+		items[i] = i
+	}
+	rule := r.Every(r.MinN(2500))
+
+	sequential := u.NewSouuup(u.Schema{"items": u.Field(items, rule)}).Validate()
+	concurrent := u.NewSouuup(u.Schema{"items": u.Field(items, rule)}).Validate(u.WithConcurrency(8))
+
+	if sequential == nil || concurrent == nil {
+		t.Fatalf("expected both runs to fail, got %v and %v", sequential, concurrent)
+	}
+	if sequential.Error() != concurrent.Error() {
+		t.Errorf("expected concurrent output to match sequential output\nwant: %s\ngot:  %s",
+			sequential.Error(), concurrent.Error())
+	}
+}
+
+func TestSlices_ShortCircuit(t *testing.T) {
+	t.Run("Every reports only the first failing element", func(t *testing.T) {
+		calls := 0
+		rule := func(fs u.FieldState[int]) error {
+			calls++
+			if fs.Value < 0 {
+				return fmt.Errorf("%d is negative", fs.Value)
+			}
+			return nil
+		}
+
+		err := u.NewSouuup(u.Schema{
+			"items": u.Field([]int{1, -2, -3, 4}, r.Every(rule)),
+		}).Validate(u.WithBail())
+
+		expected := `{"items":{"errors":["1 element failed validation\n  [1]: -2 is negative"]}}`
+		testutil.CheckError(t, err, true, expected)
+		if calls != 2 {
+			t.Errorf("expected Every to stop at the first failure, but rule was called %d times", calls)
+		}
+	})
+
+	t.Run("Every reports the first failing element with concurrency", func(t *testing.T) {
+		items := make([]int, 1000)
+		for i := range items {
+			items[i] = i
+		}
+
+		err := u.NewSouuup(u.Schema{
+			"items": u.Field(items, r.Every(r.MaxN(100))),
+		}).Validate(u.WithFailFast(), u.WithConcurrency(8))
+
+		expected := `{"items":{"errors":["1 element failed validation\n  [101]: value is 101, but needs to be at most 100"]}}`
+		testutil.CheckError(t, err, true, expected)
+	})
+
+	t.Run("Some stops at the first passing element", func(t *testing.T) {
+		calls := 0
+		rule := func(fs u.FieldState[int]) error {
+			calls++
+			if fs.Value%2 != 0 {
+				return fmt.Errorf("%d is not even", fs.Value)
+			}
+			return nil
+		}
+
+		err := u.NewSouuup(u.Schema{
+			"items": u.Field([]int{1, 2, 3, 4}, r.Some(rule)),
+		}).Validate(u.WithFailFast())
+
+		testutil.CheckError(t, err, false, "")
+		if calls != 2 {
+			t.Errorf("expected Some to stop at the first pass, but rule was called %d times", calls)
+		}
+	})
+
+	t.Run("None reports only the first passing element", func(t *testing.T) {
+		err := u.NewSouuup(u.Schema{
+			"items": u.Field([]int{1, 2, 3}, r.None(r.MinN(2))),
+		}).Validate(u.WithFailFast())
+
+		expected := `{"items":{"errors":["1 element unexpectedly passed validation (expected none to pass rule)\n  [1]: failed"]}}`
+		testutil.CheckError(t, err, true, expected)
+	})
+}
+
+func TestEvery_ElementOrder(t *testing.T) {
+	items := []string{"a", "bbb", "c", "ddd", "eee", "fff", "ggg", "hhh", "iii", "jjj", "k"}
+
+	err := r.Every(r.MinS(3))(u.FieldState[[]string]{Value: items})
+
+	expected := "3 elements failed validation\n" +
+		"  [0]: length is 1, but needs to be at least 3\n" +
+		"  [2]: length is 1, but needs to be at least 3\n" +
+		"  [10]: length is 1, but needs to be at least 3"
+	testutil.CheckError(t, err, true, expected)
+}
+
+func TestEvery_ElementState(t *testing.T) {
+	// Arrange
+	var paths []string
+	var parents []any
+	items := []string{"a", "b"}
+	schema := u.Schema{
+		"tags": u.Field(items, r.Every(func(fs u.FieldState[string]) error {
+			paths = append(paths, u.JSONPointer.Format(fs.Path()))
+			parents = append(parents, fs.Parent())
+			return nil
+		})),
+	}
+
+	// Act
+	_ = u.NewSouuup(schema).Validate()
+
+	// Assert
+	if expected := []string{"/tags/0", "/tags/1"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+	for _, parent := range parents {
+		if !reflect.DeepEqual(parent, items) {
+			t.Errorf("expected the slice as the parent of its elements, got %v", parent)
+		}
+	}
+}
+
+func TestSlices_Descriptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     u.SliceRule[string]
+		code     string
+		expected string
+	}{
+		{name: "Every", rule: r.Every(r.MinS(3)), code: r.CodeEvery, expected: "every element must be at least 3 characters long"},
+		{name: "Some", rule: r.Some(r.InS([]string{"a", "b"})), code: r.CodeSome, expected: "at least one element must be one of [a b]"},
+		{name: "None", rule: r.None(r.ContainsS("x")), code: r.CodeNone, expected: `no element may satisfy: must contain "x"`},
+		{name: "MinLen", rule: r.MinLen[string](1), code: r.CodeMinLen, expected: "must have at least 1 element"},
+		{name: "Contains", rule: r.Contains("GK"), code: r.CodeContains, expected: "must contain GK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			desc := u.DescriptorOf(tt.rule)
+
+			// Assert
+			if desc.Name != tt.code {
+				t.Errorf("expected name %q, got %q", tt.code, desc.Name)
+			}
+			if desc.Description != tt.expected {
+				t.Errorf("expected description %q, got %q", tt.expected, desc.Description)
+			}
+		})
+	}
+}
@@ -1,56 +1,70 @@
 package r
 
 import (
-	"fmt"
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cachesdev/souuup/u"
 )
 
-// MinS validates if a string's length is at least n characters.
+// Error codes reported by the string rules.
+const (
+	CodeMinS      = "string.min_length"
+	CodeMaxS      = "string.max_length"
+	CodeLenS      = "string.length"
+	CodeInS       = "string.in"
+	CodeNotInS    = "string.not_in"
+	CodeContainsS = "string.contains"
+)
+
+// MinS validates if a string's length is at least n characters. Characters are counted as
+// Unicode code points, like the minLength keyword of JSON Schema.
 //
 // Example:
 //
 //	// Validate that a name is at least 2 characters long
 //	nameField := u.Field("John", r.MinS(2))
 func MinS(n int) u.StringRule {
-	return func(fd u.FieldState[string]) error {
-		if len(fd.Value) < n {
-			return fmt.Errorf("length is %d, but needs to be at least %d", len(fd.Value), n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMinS, u.Params{"min": n}),
+		func(fd u.FieldState[string]) error {
+			if length := utf8.RuneCountInString(fd.Value); length < n {
+				return u.NewRuleError(CodeMinS, u.Params{"min": n, "actual": length})
+			}
+			return nil
+		})
 }
 
-// MaxS validates if a string's length is at most n characters.
+// MaxS validates if a string's length is at most n characters, counted as Unicode code points.
 //
 // Example:
 //
 //	// Validate that a username is at most 20 characters long
 //	usernameField := u.Field("john doe", r.MaxS(20))
 func MaxS(n int) u.StringRule {
-	return func(fd u.FieldState[string]) error {
-		if len(fd.Value) > n {
-			return fmt.Errorf("length is %d, but needs to be at most %d", len(fd.Value), n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeMaxS, u.Params{"max": n}),
+		func(fd u.FieldState[string]) error {
+			if length := utf8.RuneCountInString(fd.Value); length > n {
+				return u.NewRuleError(CodeMaxS, u.Params{"max": n, "actual": length})
+			}
+			return nil
+		})
 }
 
-// LenS validates if a string's length is exactly n characters.
+// LenS validates if a string's length is exactly n characters, counted as Unicode code points.
 //
 // Example:
 //
 //	// Validate that a passcode is exactly 6 characters long
 //	otpField := u.Field("123456", r.LenS(6))
 func LenS(n int) u.StringRule {
-	return func(fd u.FieldState[string]) error {
-		if len(fd.Value) != n {
-			return fmt.Errorf("length is %d, but needs to be exactly %d", len(fd.Value), n)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeLenS, u.Params{"length": n}),
+		func(fd u.FieldState[string]) error {
+			if length := utf8.RuneCountInString(fd.Value); length != n {
+				return u.NewRuleError(CodeLenS, u.Params{"length": n, "actual": length})
+			}
+			return nil
+		})
 }
 
 // InS validates if a string is contained within a set of strings
@@ -60,12 +74,13 @@ func LenS(n int) u.StringRule {
 //	// Validate that a size is small, medium, or large
 //	sizeField := u.Field("small", r.InS(["small", "medium", "large"]))
 func InS(set []string) u.StringRule {
-	return func(fs u.FieldState[string]) error {
-		if !slices.Contains(set, fs.Value) {
-			return fmt.Errorf("%q is not in %v, but should be", fs.Value, set)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeInS, u.Params{"set": set}),
+		func(fs u.FieldState[string]) error {
+			if !slices.Contains(set, fs.Value) {
+				return u.NewRuleError(CodeInS, u.Params{"set": set, "actual": fs.Value})
+			}
+			return nil
+		})
 }
 
 // NotInS validates if a string is not contained within a set of strings
@@ -75,12 +90,13 @@ func InS(set []string) u.StringRule {
 //	// Validate that a status is not rejected or invalid
 //	statusField := u.Field("completed", r.NotInS(["rejected", "invalid"]))
 func NotInS(set []string) u.StringRule {
-	return func(fs u.FieldState[string]) error {
-		if slices.Contains(set, fs.Value) {
-			return fmt.Errorf("%q is in %v, but shouldn't be", fs.Value, set)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeNotInS, u.Params{"set": set}),
+		func(fs u.FieldState[string]) error {
+			if slices.Contains(set, fs.Value) {
+				return u.NewRuleError(CodeNotInS, u.Params{"set": set, "actual": fs.Value})
+			}
+			return nil
+		})
 }
 
 // ContainsS validates if a string contains a substring
@@ -90,10 +106,11 @@ func NotInS(set []string) u.StringRule {
 //	// Validate that an address contains "Street"
 //	addrField := u.Field("123 London Street", r.ContainsS("Street"))
 func ContainsS(substr string) u.StringRule {
-	return func(fs u.FieldState[string]) error {
-		if !strings.Contains(fs.Value, substr) {
-			return fmt.Errorf("%q does not contain %q, but needs to", fs.Value, substr)
-		}
-		return nil
-	}
+	return u.WithDescriptor(u.NewRuleDescriptor(CodeContainsS, u.Params{"substring": substr}),
+		func(fs u.FieldState[string]) error {
+			if !strings.Contains(fs.Value, substr) {
+				return u.NewRuleError(CodeContainsS, u.Params{"substring": substr, "actual": fs.Value})
+			}
+			return nil
+		})
 }
@@ -86,6 +86,19 @@ func TestMaxS(t *testing.T) {
 			max:     5,
 			wantErr: false,
 		},
+		{
+			name:    "multi-byte characters count once",
+			value:   "é",
+			max:     1,
+			wantErr: false,
+		},
+		{
+			name:     "multi-byte characters over maximum length",
+			value:    "añoñ",
+			max:      3,
+			wantErr:  true,
+			errorMsg: "length is 4, but needs to be at most 3",
+		},
 	}
 
 	for _, tt := range tests {
@@ -281,3 +294,9 @@ func TestContainsS(t *testing.T) {
 		})
 	}
 }
+
+func TestMinS_RuleError(t *testing.T) {
+	fs := u.FieldState[string]{Value: "a"}
+	err := r.MinS(2)(fs)
+	testutil.CheckRuleError(t, err, r.CodeMinS, u.Params{"min": 2, "actual": 1})
+}
@@ -0,0 +1,567 @@
+// Package tags builds souuup schemas from struct tags.
+//
+// Fields are validated with the rules of their souuup tag, which map onto the rules of the
+// r package, and are reported under the name of their json tag:
+//
+//	type UserRegistration struct {
+//		Username string   `json:"username" souuup:"required,min=3,max=20"`
+//		Age      int      `json:"age" souuup:"min=18"`
+//		Size     string   `json:"size" souuup:"in=small|medium|large"`
+//		Address  *Address `json:"address" souuup:"required"`
+//	}
+//
+//	schema, err := tags.FromStruct(reg)
+//	if err != nil {
+//		return err // invalid tags
+//	}
+//	schema["password"] = u.Field(reg.Password, StrongPasswordRule)
+//	err = u.NewSouuup(schema).Validate()
+//
+// The supported rules are:
+//
+//	required        value is not zero; pointers are not nil and collections are not empty
+//	min=n, max=n    minimum/maximum of a number, or length of a string, slice, array or map
+//	len=n           exact length of a string, slice, array or map
+//	gt=n, lt=n      number is greater/less than n
+//	gte=n, lte=n    aliases of min and max for numbers
+//	neq=n           number is not equal to n
+//	in=a|b|c        string is one of the given values
+//	notin=a|b|c     string is none of the given values
+//	contains=s      string contains s
+//
+// Nested structs, and pointers to them, are validated as nested schemas, and do not support
+// rules: required is written on a pointer to the struct. Structs without exported fields, such
+// as time.Time, are validated as values, and only support required, like booleans. Slices,
+// arrays and maps of structs are validated as nested schemas keyed by index or map key.
+// Embedded structs without a json name have their fields promoted, as with encoding/json.
+// Fields tagged with `souuup:"-"` or `json:"-"`, and unexported fields, are skipped.
+//
+// Tags are checked against the types of their fields, whatever their values, so the same tags
+// are accepted as by the souuupgen command.
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/cachesdev/souuup/internal/structtag"
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/u"
+)
+
+// FromStruct builds a schema from the struct tags of v, which must be a struct or a pointer
+// to one. The values of v are captured when the schema is built. An error is returned if a
+// tag of the struct type, or of the struct types of its fields, contains an unknown rule, or a
+// rule that does not apply to the type of its field.
+func FromStruct(v any) (u.Schema, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("tags: FromStruct of nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tags: FromStruct of non-struct type %s", rv.Type())
+	}
+
+	if err := checkStruct(rv.Type(), make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+	return fromStruct(rv)
+}
+
+// MustFromStruct is like FromStruct but panics if the schema cannot be built.
+func MustFromStruct(v any) u.Schema {
+	schema, err := FromStruct(v)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// checkStruct checks the tags of the fields of a struct type, and of the struct types contained
+// in them, so that tags are rejected even when the values of their fields are nil or empty.
+func checkStruct(rt reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[rt] {
+		return nil
+	}
+	seen[rt] = true
+
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+
+		rules, skip, err := structtag.Parse(sf.Tag.Get(structtag.Key))
+		if err != nil {
+			return fmt.Errorf("tags: field %s.%s: %w", rt, sf.Name, err)
+		}
+		if _, skipJSON := structtag.FieldName(sf.Name, sf.Tag.Get("json")); skip || skipJSON {
+			continue
+		}
+
+		if sf.Anonymous && len(rules) == 0 && sf.Tag.Get("json") == "" {
+			if embedded := elemType(sf.Type); embedded.Kind() == reflect.Struct {
+				if err := checkStruct(embedded, seen); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if err := checkField(sf.Type, rules); err != nil {
+			return fmt.Errorf("tags: field %s.%s: %w", rt, sf.Name, err)
+		}
+		if nested, ok := containedStruct(sf.Type); ok {
+			if err := checkStruct(nested, seen); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkField checks the rules of a field of type rt. Pointers support required, and the rules
+// of their element.
+func checkField(rt reflect.Type, rules []structtag.Rule) error {
+	if rt.Kind() == reflect.Pointer {
+		return checkField(rt.Elem(), without(rules, structtag.Required))
+	}
+	return structtag.Check(rules, tagType(rt))
+}
+
+// tagType describes a type for checking tags, with the kind of its values which determines the
+// rules they support.
+func tagType(rt reflect.Type) structtag.Type {
+	typ := structtag.Type{Kind: structtag.KindOther, Name: rt.String()}
+	switch rt.Kind() {
+	case reflect.String:
+		typ.Kind = structtag.KindString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		typ.Kind, typ.Bits = structtag.KindInt, rt.Bits()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		typ.Kind, typ.Bits = structtag.KindUint, rt.Bits()
+	case reflect.Float32, reflect.Float64:
+		typ.Kind, typ.Bits = structtag.KindFloat, rt.Bits()
+	case reflect.Slice, reflect.Array, reflect.Map:
+		typ.Kind = structtag.KindCollection
+	case reflect.Struct:
+		if isSchema(rt) {
+			typ.Kind = structtag.KindStruct
+		}
+	}
+	return typ
+}
+
+// isSchema reports whether a struct type is validated as a nested schema, which is the case
+// for structs with exported fields.
+func isSchema(rt reflect.Type) bool {
+	for i := range rt.NumField() {
+		if rt.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// containedStruct returns the struct type contained in a field type, directly or as the element
+// of pointers, slices, arrays and maps.
+func containedStruct(rt reflect.Type) (reflect.Type, bool) {
+	for {
+		switch rt.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			rt = rt.Elem()
+		case reflect.Struct:
+			return rt, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// elemType returns the type pointed to by a pointer type, or the type itself.
+func elemType(rt reflect.Type) reflect.Type {
+	if rt.Kind() == reflect.Pointer {
+		return rt.Elem()
+	}
+	return rt
+}
+
+// fromStruct builds the schema of a struct value. Its tags are checked by checkStruct.
+func fromStruct(rv reflect.Value) (u.Schema, error) {
+	schema := u.Schema{}
+	if err := addFields(schema, rv); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// addFields adds the fields of a struct value to schema, promoting the fields of embedded structs.
+func addFields(schema u.Schema, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+
+		rules, skip, err := structtag.Parse(sf.Tag.Get(structtag.Key))
+		if err != nil {
+			return fmt.Errorf("tags: field %s.%s: %w", rt, sf.Name, err)
+		}
+		name, skipJSON := structtag.FieldName(sf.Name, sf.Tag.Get("json"))
+		if skip || skipJSON {
+			continue
+		}
+
+		if sf.Anonymous && len(rules) == 0 && sf.Tag.Get("json") == "" {
+			if embedded, ok := embeddedStruct(rv.Field(i)); ok {
+				if err := addFields(schema, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		field, err := fieldFor(rv.Field(i), rules)
+		if err != nil {
+			return fmt.Errorf("tags: field %s.%s: %w", rt, sf.Name, err)
+		}
+		if field != nil {
+			schema[name] = field
+		}
+	}
+
+	return nil
+}
+
+// embeddedStruct returns the struct value of an embedded field, if it is a struct or a
+// non-nil pointer to one.
+func embeddedStruct(rv reflect.Value) (reflect.Value, bool) {
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// fieldFor returns the validatable entity for a field value and its rules. It returns nil if
+// there is nothing to validate.
+func fieldFor(rv reflect.Value, rules []structtag.Rule) (u.Validable, error) {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nilField(rules), nil
+		}
+		return fieldFor(rv.Elem(), without(rules, structtag.Required))
+
+	case reflect.String:
+		return stringField(rv.String(), rules)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numericField(rv.Int(), rules, func(rule structtag.Rule) (int64, error) {
+			return rule.Int(rv.Type().Bits())
+		})
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return numericField(rv.Uint(), rules, func(rule structtag.Rule) (uint64, error) {
+			return rule.Uint(rv.Type().Bits())
+		})
+
+	case reflect.Float32, reflect.Float64:
+		return numericField(rv.Float(), rules, func(rule structtag.Rule) (float64, error) {
+			return rule.Float(rv.Type().Bits())
+		})
+
+	case reflect.Struct:
+		return structField(rv, rules)
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return collectionField(rv, rules)
+
+	case reflect.Bool, reflect.Complex64, reflect.Complex128, reflect.Chan, reflect.Func,
+		reflect.UnsafePointer, reflect.Invalid:
+		return otherField(rv, rules), nil
+	}
+
+	return otherField(rv, rules), nil
+}
+
+// nilField returns the field of a nil pointer or interface, which only fails if it is required.
+func nilField(rules []structtag.Rule) u.Validable {
+	if !has(rules, structtag.Required) {
+		return nil
+	}
+	return presence(false)
+}
+
+// stringField builds the field of a string value.
+func stringField(value string, rules []structtag.Rule) (u.Validable, error) {
+	var stringRules []u.StringRule
+	for _, rule := range rules {
+		if rule.Name == structtag.Required {
+			stringRules = append(stringRules, r.NotZero[string])
+			continue
+		}
+		if rule.Name == structtag.In || rule.Name == structtag.NotIn || rule.Name == structtag.Contains {
+			stringRules = append(stringRules, setRule(rule))
+			continue
+		}
+
+		n, err := rule.Length()
+		if err != nil {
+			return nil, err
+		}
+
+		switch rule.Name {
+		case structtag.Min:
+			stringRules = append(stringRules, r.MinS(n))
+		case structtag.Max:
+			stringRules = append(stringRules, r.MaxS(n))
+		case structtag.Len:
+			stringRules = append(stringRules, r.LenS(n))
+		}
+	}
+	return u.Field(value, stringRules...), nil
+}
+
+// setRule returns the string rule of an in, notin or contains tag rule.
+func setRule(rule structtag.Rule) u.StringRule {
+	switch rule.Name {
+	case structtag.In:
+		return r.InS(rule.List())
+	case structtag.NotIn:
+		return r.NotInS(rule.List())
+	default:
+		return r.ContainsS(rule.Arg)
+	}
+}
+
+// numericField builds the field of a numeric value, parsing rule arguments with parse.
+func numericField[T u.Numeric](value T, rules []structtag.Rule, parse func(structtag.Rule) (T, error)) (u.Validable, error) {
+	var numericRules []u.NumericRule[T]
+	for _, rule := range rules {
+		if rule.Name == structtag.Required {
+			numericRules = append(numericRules, r.NotZero[T])
+			continue
+		}
+
+		n, err := parse(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		switch rule.Name {
+		case structtag.Min, structtag.Gte:
+			numericRules = append(numericRules, r.MinN(n))
+		case structtag.Max, structtag.Lte:
+			numericRules = append(numericRules, r.MaxN(n))
+		case structtag.Gt:
+			numericRules = append(numericRules, r.Gt(n))
+		case structtag.Lt:
+			numericRules = append(numericRules, r.Lt(n))
+		case structtag.Neq:
+			numericRules = append(numericRules, r.NeqN(n))
+		}
+	}
+	return u.Field(value, numericRules...), nil
+}
+
+// structField builds the field of a struct value, which is a nested schema if it has exported
+// fields, and is otherwise validated like a value of any other kind.
+func structField(rv reflect.Value, rules []structtag.Rule) (u.Validable, error) {
+	if !isSchema(rv.Type()) {
+		return otherField(rv, rules), nil
+	}
+	return fromStruct(rv)
+}
+
+// collectionField builds the field of a slice, array or map value, validating its length and
+// any struct elements.
+func collectionField(rv reflect.Value, rules []structtag.Rule) (u.Validable, error) {
+	var field u.Validable
+	if len(rules) > 0 {
+		var err error
+		if rv.Kind() == reflect.Map {
+			field, err = mapLengthField(rv.Len(), rules)
+		} else {
+			field, err = sliceLengthField(rv.Len(), rules)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	elems, err := elementsObject(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case elems == nil:
+		return field, nil
+	case field == nil:
+		return elems, nil
+	default:
+		return u.WithNested(field, elems), nil
+	}
+}
+
+// sliceLengthField builds the field validating the length of a slice or array of n elements.
+// The length rules only depend on the number of elements, not their type, so they validate a
+// slice of n untyped elements.
+func sliceLengthField(n int, rules []structtag.Rule) (u.Validable, error) {
+	elements := make([]any, n)
+
+	var lengthRules []u.SliceRule[any]
+	for _, rule := range rules {
+		if rule.Name == structtag.Required {
+			lengthRules = append(lengthRules, r.MinLen[any](1))
+			continue
+		}
+
+		length, err := rule.Length()
+		if err != nil {
+			return nil, err
+		}
+
+		switch rule.Name {
+		case structtag.Min:
+			lengthRules = append(lengthRules, r.MinLen[any](length))
+		case structtag.Max:
+			lengthRules = append(lengthRules, r.MaxLen[any](length))
+		case structtag.Len:
+			lengthRules = append(lengthRules, r.ExactLen[any](length))
+		}
+	}
+	return u.Field(elements, lengthRules...), nil
+}
+
+// mapLengthField builds the field validating the number of keys of a map of n keys, like
+// sliceLengthField. Maps have no exact length rule, so len is validated as both a minimum and a
+// maximum number of keys.
+func mapLengthField(n int, rules []structtag.Rule) (u.Validable, error) {
+	keys := make(map[int]struct{}, n)
+	for i := range n {
+		keys[i] = struct{}{}
+	}
+
+	var keyRules []u.MapRule[int, struct{}]
+	for _, rule := range rules {
+		if rule.Name == structtag.Required {
+			keyRules = append(keyRules, r.MinKeys[int, struct{}](1))
+			continue
+		}
+
+		length, err := rule.Length()
+		if err != nil {
+			return nil, err
+		}
+
+		switch rule.Name {
+		case structtag.Min:
+			keyRules = append(keyRules, r.MinKeys[int, struct{}](length))
+		case structtag.Max:
+			keyRules = append(keyRules, r.MaxKeys[int, struct{}](length))
+		case structtag.Len:
+			keyRules = append(keyRules, r.MinKeys[int, struct{}](length), r.MaxKeys[int, struct{}](length))
+		}
+	}
+	return u.Field(keys, keyRules...), nil
+}
+
+// elementsObject returns a schema validating the struct elements of a slice, array or map,
+// keyed by index or map key. It returns nil if the elements are not structs.
+func elementsObject(rv reflect.Value) (u.Object, error) {
+	elemType := rv.Type().Elem()
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, nil //nolint:nilnil // no nested schema for non-struct elements
+	}
+
+	if rv.Kind() == reflect.Map {
+		schema := u.Schema{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := addElement(schema, fmt.Sprint(iter.Key()), iter.Value()); err != nil {
+				return nil, err
+			}
+		}
+		return schema, nil
+	}
+
+	entries := u.Ordered()
+	for i := range rv.Len() {
+		schema := u.Schema{}
+		if err := addElement(schema, strconv.Itoa(i), rv.Index(i)); err != nil {
+			return nil, err
+		}
+		if field, ok := schema[strconv.Itoa(i)]; ok {
+			entries = append(entries, u.Entry(strconv.Itoa(i), field))
+		}
+	}
+	return entries, nil
+}
+
+// addElement adds the schema of a struct element to schema under tag. Nil elements are skipped.
+func addElement(schema u.Schema, tag u.FieldTag, rv reflect.Value) error {
+	elem, ok := embeddedStruct(rv)
+	if !ok {
+		return nil
+	}
+
+	elemSchema, err := fromStruct(elem)
+	if err != nil {
+		return err
+	}
+	schema[tag] = elemSchema
+	return nil
+}
+
+// otherField builds the field of a value of any other kind, which only supports required.
+func otherField(rv reflect.Value, rules []structtag.Rule) u.Validable {
+	if len(rules) == 0 {
+		return nil
+	}
+	return presence(!rv.IsZero())
+}
+
+// presence returns a required field for a value of any type, given whether it is present.
+// Values of unexported embedded structs cannot be read through reflection, so required is
+// validated from the presence of the value, rather than the value itself.
+func presence(present bool) u.Validable {
+	return u.Field(present, r.NotZero[bool])
+}
+
+// has reports whether rules contain a rule with the given name.
+func has(rules []structtag.Rule, name string) bool {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns rules without the rules with the given name.
+func without(rules []structtag.Rule, name string) []structtag.Rule {
+	var result []structtag.Rule
+	for _, rule := range rules {
+		if rule.Name != name {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
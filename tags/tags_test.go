@@ -0,0 +1,276 @@
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/cachesdev/souuup/r"
+	"github.com/cachesdev/souuup/tags"
+	"github.com/cachesdev/souuup/u"
+)
+
+type address struct {
+	Street string `json:"street" souuup:"required"`
+	City   string `json:"city" souuup:"min=2"`
+}
+
+type item struct {
+	SKU      string `json:"sku" souuup:"len=4"`
+	Quantity uint   `json:"qty" souuup:"gt=0,lte=10"`
+}
+
+type timestamps struct {
+	CreatedBy string `json:"createdBy" souuup:"required"`
+}
+
+type order struct {
+	timestamps
+
+	ID       int64             `json:"id" souuup:"required"`
+	Username string            `json:"username" souuup:"required,min=3,max=20"`
+	Size     string            `json:"size" souuup:"in=small|medium|large"`
+	Discount float64           `json:"discount,omitempty" souuup:"min=0,lt=1"`
+	Address  *address          `json:"address" souuup:"required"`
+	Billing  *address          `json:"billing"`
+	Items    []item            `json:"items" souuup:"min=1"`
+	Tags     []string          `json:"tags" souuup:"max=2"`
+	Labels   map[string]string `json:"labels" souuup:"required"`
+	Notes    string            `json:"-" souuup:"required"`
+	Internal string            `souuup:"-"`
+	secret   string
+}
+
+func TestFromStruct(t *testing.T) {
+	valid := func() order {
+		return order{
+			timestamps: timestamps{CreatedBy: "admin"},
+			ID:         1,
+			Username:   "johndoe",
+			Size:       "small",
+			Discount:   0.5,
+			Address:    &address{Street: "Main St", City: "London"},
+			Items:      []item{{SKU: "A001", Quantity: 1}},
+			Tags:       []string{"new"},
+			Labels:     map[string]string{"team": "core"},
+			secret:     "s3cr3t",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(o *order)
+		errorMsg string
+	}{
+		{
+			name:   "valid struct",
+			modify: func(*order) {},
+		},
+		{
+			name: "scalar fields",
+			modify: func(o *order) {
+				o.ID = 0
+				o.Username = "jo"
+				o.Size = "huge"
+				o.Discount = 1
+			},
+			errorMsg: `{"discount":{"errors":["value is 1, but needs to be less than 1"]},` +
+				`"id":{"errors":["value is required but has zero value"]},` +
+				`"size":{"errors":["\"huge\" is not in [small medium large], but should be"]},` +
+				`"username":{"errors":["length is 2, but needs to be at least 3"]}}`,
+		},
+		{
+			name: "nil required pointer",
+			modify: func(o *order) {
+				o.Address = nil
+			},
+			errorMsg: `{"address":{"errors":["value is required but has zero value"]}}`,
+		},
+		{
+			name: "nested struct fields",
+			modify: func(o *order) {
+				o.Address.City = "L"
+				o.Billing = &address{City: "Paris"}
+			},
+			errorMsg: `{"address":{"city":{"errors":["length is 1, but needs to be at least 2"]}},` +
+				`"billing":{"street":{"errors":["value is required but has zero value"]}}}`,
+		},
+		{
+			name: "slice length and struct elements",
+			modify: func(o *order) {
+				o.Items = nil
+				o.Tags = []string{"a", "b", "c"}
+			},
+			errorMsg: `{"items":{"errors":["length is 0, but needs to be at least 1"]},` +
+				`"tags":{"errors":["length is 3, but needs to be at most 2"]}}`,
+		},
+		{
+			name: "slice elements are keyed by index",
+			modify: func(o *order) {
+				o.Items = append(o.Items, item{SKU: "B2", Quantity: 11})
+			},
+			errorMsg: `{"items":{"1":{"qty":{"errors":["value is 11, but needs to be at most 10"]},` +
+				`"sku":{"errors":["length is 2, but needs to be exactly 4"]}}}}`,
+		},
+		{
+			name: "promoted fields of embedded structs and empty maps",
+			modify: func(o *order) {
+				o.CreatedBy = ""
+				o.Labels = map[string]string{}
+			},
+			errorMsg: `{"createdBy":{"errors":["value is required but has zero value"]},` +
+				`"labels":{"errors":["has 0 keys, but needs at least 1"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			o := valid()
+			tt.modify(&o)
+
+			// Act
+			schema, err := tags.FromStruct(&o)
+			if err != nil {
+				t.Fatalf("unexpected error building schema: %v", err)
+			}
+			err = u.NewSouuup(schema).Validate()
+
+			// Assert
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.errorMsg {
+				t.Errorf("expected error %s, got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestFromStruct_MapsOfStructs(t *testing.T) {
+	// Arrange
+	v := struct {
+		Addresses map[string]address `json:"addresses"`
+	}{
+		Addresses: map[string]address{
+			"home": {Street: "Main St", City: "London"},
+			"work": {City: "Paris"},
+		},
+	}
+
+	// Act
+	err := u.NewSouuup(tags.MustFromStruct(v)).Validate()
+
+	// Assert
+	expected := `{"addresses":{"work":{"street":{"errors":["value is required but has zero value"]}}}}`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %s, got %v", expected, err)
+	}
+}
+
+func TestFromStruct_MixedWithHandWrittenFields(t *testing.T) {
+	// Arrange
+	a := address{Street: "Main St", City: "London"}
+	schema := tags.MustFromStruct(a)
+	schema["city"] = u.Field(a.City, r.InS([]string{"Paris"}))
+
+	// Act
+	err := u.NewSouuup(schema).Validate()
+
+	// Assert
+	expected := `{"city":{"errors":["\"London\" is not in [Paris], but should be"]}}`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %s, got %v", expected, err)
+	}
+}
+
+func TestFromStruct_InvalidTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "not a struct",
+			value: "username",
+		},
+		{
+			name:  "nil pointer",
+			value: (*address)(nil),
+		},
+		{
+			name: "unknown rule",
+			value: struct {
+				Email string `souuup:"email"`
+			}{},
+		},
+		{
+			name: "rule not supported for the type",
+			value: struct {
+				Admin bool `souuup:"min=1"`
+			}{},
+		},
+		{
+			name: "invalid number",
+			value: struct {
+				Age int `souuup:"min=eighteen"`
+			}{},
+		},
+		{
+			name: "number overflowing the type",
+			value: struct {
+				Age int8 `souuup:"min=300"`
+			}{},
+		},
+		{
+			name: "non-finite number",
+			value: struct {
+				Price float64 `souuup:"max=NaN"`
+			}{},
+		},
+		{
+			name: "required on struct values",
+			value: struct {
+				Address address `souuup:"required"`
+			}{},
+		},
+		{
+			name: "invalid rule of a nil pointer",
+			value: struct {
+				Nickname *string `souuup:"min=three"`
+			}{},
+		},
+		{
+			name: "invalid rule of the elements of an empty slice",
+			value: struct {
+				Items []struct {
+					Qty int `souuup:"len=1"`
+				}
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			schema, err := tags.FromStruct(tt.value)
+
+			// Assert
+			if err == nil {
+				t.Errorf("expected an error, got schema %v", schema)
+			}
+		})
+	}
+
+	t.Run("MustFromStruct panics", func(t *testing.T) {
+		defer func() {
+			if rec := recover(); rec == nil {
+				t.Error("expected MustFromStruct to panic")
+			} else if _, ok := rec.(error); !ok {
+				t.Errorf("expected an error, got %v", rec)
+			}
+		}()
+		tags.MustFromStruct(42)
+	})
+
+}
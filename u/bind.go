@@ -0,0 +1,83 @@
+package u
+
+// boundSchema validates an object for the value it is bound to.
+type boundSchema struct {
+	value  any
+	schema Object
+}
+
+var (
+	_ Object    = (*boundSchema)(nil)
+	_ Describer = (*boundSchema)(nil)
+)
+
+// Bind returns an object that validates schema as the schema of value, so that its rules can read
+// value with FieldState.Parent, and with FieldState.Root when it is the root of the validation run.
+// The schemas of Compile, Each and WithRules are bound to their values.
+//
+// Example:
+//
+//	schema := u.Schema{
+//		"shipping": u.Bind(order.Shipping, u.Schema{
+//			"date": u.Field(order.Shipping.Date, AfterOrderDate),
+//		}),
+//	}
+//
+//	func AfterOrderDate(fs u.FieldState[time.Time]) error {
+//		order, _ := fs.Root().(Order)
+//		if fs.Value.Before(order.Date) {
+//			return errors.New("must be after the date of the order")
+//		}
+//		return nil
+//	}
+func Bind[T any](value T, schema Object) Object {
+	return &boundSchema{value: value, schema: schema}
+}
+
+// Validate implements the Validable interface for boundSchema.
+func (s *boundSchema) Validate(ve *ValidationError, tag FieldTag) {
+	ve.bind(s.value)
+	s.schema.Validate(ve, tag)
+}
+
+// Entries implements the Object interface for boundSchema, returning the entries of its schema.
+func (s *boundSchema) Entries() []SchemaEntry {
+	return s.schema.Entries()
+}
+
+// Errors implements the Validable interface for boundSchema.
+func (s *boundSchema) Errors() *ValidationError {
+	errors := NewValidationError()
+	s.Validate(errors, "")
+	return errors
+}
+
+// Describe implements the Describer interface for boundSchema, describing its schema.
+func (s *boundSchema) Describe() FieldDescription {
+	return describe(s.schema)
+}
+
+// bind binds value to ve, as the value its fields belong to. It is the root value of the
+// validation run if ve is the root of the tree.
+func (ve *ValidationError) bind(value any) {
+	ve.run.lock()
+	defer ve.run.unlock()
+
+	ve.value = value
+	if ve.Parent == nil && ve.run != nil {
+		ve.run.root = value
+	}
+}
+
+// boundValue returns the value bound to ve or, if there is none, to its nearest parent.
+func (ve *ValidationError) boundValue() any {
+	ve.run.lock()
+	defer ve.run.unlock()
+
+	for node := ve; node != nil; node = node.Parent {
+		if node.value != nil {
+			return node.value
+		}
+	}
+	return nil
+}
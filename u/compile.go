@@ -0,0 +1,81 @@
+package u
+
+import (
+	"context"
+	"slices"
+)
+
+// Validator validates values of type T with the schema returned by a schema definition function.
+// It is built once with Compile and is safe for concurrent use: every validation builds the
+// schema of its value and its own errors, so nothing is shared between validations.
+type Validator[T any] struct {
+	schema func(T) Object
+	opts   []Option
+}
+
+var _ Describer = (*Validator[any])(nil)
+
+// Compile returns a Validator validating values with the schema returned by schema for them,
+// bound to the value, see Bind. The options are applied to every validation, before the options
+// given to it.
+//
+// The schemas built by a Validator are not returned, so the values transformed by Transform are
+// discarded once validated. To store the transformed values, build the schema and validate it
+// with NewSouuup instead, reading them with FieldDef.Value.
+//
+// Example:
+//
+//	var userValidator = u.Compile(func(user User) u.Schema {
+//		return u.Schema{
+//			"username": u.Field(user.Name, r.MinS(3), r.MaxS(20)),
+//			"age":      u.Field(user.Age, r.MinN(18)),
+//		}
+//	}, u.WithLocale("es"))
+//
+//	func createUser(w http.ResponseWriter, req *http.Request) {
+//		...
+//		if err := userValidator.Validate(user); err != nil {
+//			...
+//		}
+//	}
+func Compile[T any, S Object](schema func(T) S, opts ...Option) *Validator[T] {
+	return &Validator[T]{
+		schema: func(value T) Object { return schema(value) },
+		opts:   opts,
+	}
+}
+
+// Validate validates value and returns an error if validation fails, see Souuup.Validate.
+func (v *Validator[T]) Validate(value T, opts ...Option) error {
+	return v.ValidateContext(context.Background(), value, opts...)
+}
+
+// ValidateContext is like Validate, but the context is made available to every rule, see
+// Souuup.ValidateContext.
+func (v *Validator[T]) ValidateContext(ctx context.Context, value T, opts ...Option) error {
+	return NewSouuup(Bind(value, v.schema(value))).ValidateContext(ctx, v.options(opts)...)
+}
+
+// Check validates value and returns every failure by severity, see Souuup.Check.
+func (v *Validator[T]) Check(value T, opts ...Option) (*Result, error) {
+	return v.CheckContext(context.Background(), value, opts...)
+}
+
+// CheckContext is like Check, but the context is made available to every rule, see
+// Souuup.ValidateContext.
+func (v *Validator[T]) CheckContext(ctx context.Context, value T, opts ...Option) (*Result, error) {
+	return NewSouuup(Bind(value, v.schema(value))).CheckContext(ctx, v.options(opts)...)
+}
+
+// Describe implements the Describer interface for Validator, describing the schema of the zero
+// value of T. Fields and rules that depend on the value, such as those of If and When, are
+// described as they apply to the zero value.
+func (v *Validator[T]) Describe() FieldDescription {
+	var zero T
+	return describe(v.schema(zero))
+}
+
+// options returns the options of the validator followed by opts.
+func (v *Validator[T]) options(opts []Option) []Option {
+	return append(slices.Clip(v.opts), opts...)
+}
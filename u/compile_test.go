@@ -0,0 +1,184 @@
+package u_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+type account struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func accountSchema(a account) u.Schema {
+	minAge := func(fs u.FieldState[int]) error {
+		if fs.Value < 18 {
+			return fmt.Errorf("%d is under 18", fs.Value)
+		}
+		return nil
+	}
+	notEmpty := func(fs u.FieldState[string]) error {
+		if fs.Value == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}
+
+	return u.Schema{
+		"name":  u.Field(a.Name, notEmpty),
+		"email": u.Field(a.Email, u.Transform(strings.TrimSpace), notEmpty),
+		"age":   u.Field(a.Age, minAge),
+	}
+}
+
+func TestCompile(t *testing.T) {
+	validator := u.Compile(accountSchema)
+
+	tests := []struct {
+		name     string
+		value    account
+		opts     []u.Option
+		expected string
+	}{
+		{
+			name:  "valid value",
+			value: account{Name: "John", Email: "john@example.com", Age: 30},
+		},
+		{
+			name:     "invalid value",
+			value:    account{Name: "John", Email: "  ", Age: 12},
+			expected: `{"age":{"errors":["12 is under 18"]},"email":{"errors":["is required"]}}`,
+		},
+		{
+			name:     "options of the validation",
+			value:    account{Email: "john@example.com", Age: 12},
+			opts:     []u.Option{u.WithMaxErrors(1)},
+			expected: `{"age":{"errors":["12 is under 18"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validator.Validate(tt.value, tt.opts...)
+
+			// Assert
+			if messagesOf(asValidationError(t, err)) != tt.expected {
+				t.Errorf("expected %s, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCompile_Options(t *testing.T) {
+	// Arrange
+	validator := u.Compile(accountSchema, u.WithMaxErrors(1))
+
+	// Act
+	res, err := validator.Check(account{Age: 12}, u.WithMaxErrors(2))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"age":{"errors":["12 is under 18"]},"email":{"errors":["is required"]}}`
+	if got := messagesOf(res.Errors); got != expected {
+		t.Errorf("expected the options of the validation to override those of the validator: %s, got %s", expected, got)
+	}
+}
+
+func TestValidator_Concurrent(t *testing.T) {
+	// Arrange
+	validator := u.Compile(accountSchema, u.WithConcurrency(4))
+	const validations = 100
+
+	// Act
+	errs := make([]error, validations)
+	var wg sync.WaitGroup
+	for i := range validations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = validator.Validate(account{Name: "John", Email: "john@example.com", Age: i})
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	for i, err := range errs {
+		var expected string
+		if i < 18 {
+			expected = fmt.Sprintf(`{"age":{"errors":["%d is under 18"]}}`, i)
+		}
+		if got := messagesOf(asValidationError(t, err)); got != expected {
+			t.Errorf("expected %s for age %d, got %s", expected, i, got)
+		}
+	}
+}
+
+func TestSouuup_Validate_Repeated(t *testing.T) {
+	// Arrange
+	s := u.NewSouuup(accountSchema(account{Age: 12}))
+	expected := `{"age":{"errors":["12 is under 18"]},"email":{"errors":["is required"]},"name":{"errors":["is required"]}}`
+
+	for i := range 3 {
+		// Act
+		err := s.Validate()
+
+		// Assert
+		if got := messagesOf(asValidationError(t, err)); got != expected {
+			t.Errorf("validation %d: expected %s, got %s", i, expected, got)
+		}
+	}
+}
+
+func TestSouuup_Validate_SharedAcrossGoroutines(t *testing.T) {
+	// Arrange
+	email := u.Field(" john@example.com ", u.Transform(strings.TrimSpace))
+	s := u.NewSouuup(u.Schema{"email": email, "age": u.Field(12, func(u.FieldState[int]) error {
+		return errors.New("is under 18")
+	})})
+	expected := `{"age":{"errors":["is under 18"]}}`
+
+	// Act
+	errs := make([]error, 50)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.Validate()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	for _, err := range errs {
+		if got := messagesOf(asValidationError(t, err)); got != expected {
+			t.Errorf("expected %s, got %s", expected, got)
+		}
+	}
+	if email.Value() != "john@example.com" {
+		t.Errorf("expected the transformed value, got %q", email.Value())
+	}
+}
+
+// asValidationError returns err as a *ValidationError, failing the test if it is another error.
+func asValidationError(t *testing.T, err error) *u.ValidationError {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var ve *u.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	return ve
+}
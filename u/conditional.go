@@ -0,0 +1,124 @@
+package u
+
+// Descriptor names of the conditional rules.
+const (
+	CodeWhen   = "rule.when"
+	CodeUnless = "rule.unless"
+)
+
+// When returns a rule that validates values with rules only if cond is true. The rules are
+// applied in order, and every failure is reported on the field, just as if they were given
+// to Field directly. When the validation run bails, the rules stop at the first failure.
+//
+// Example:
+//
+//	// Card details are only required when paying by card
+//	"cardLastFour": u.Field(payment.CardLastFour,
+//		u.When(payment.Method == "card", r.NotZero, r.LenS(4)),
+//	)
+func When[T any](cond bool, rules ...Rule[T]) Rule[T] {
+	return conditional(CodeWhen, cond, cond, rules)
+}
+
+// Unless returns a rule that validates values with rules only if cond is false. It is the
+// inverse of When.
+//
+// Example:
+//
+//	// A shipping address is needed unless the order is picked up
+//	"street": u.Field(order.Street, u.Unless(order.Pickup, r.NotZero)),
+func Unless[T any](cond bool, rules ...Rule[T]) Rule[T] {
+	return conditional(CodeUnless, cond, !cond, rules)
+}
+
+// conditional returns a rule named name that applies rules if active, described with the
+// condition it was created with and the descriptors of its rules.
+func conditional[T any](name string, cond, active bool, rules []Rule[T]) Rule[T] {
+	return WithDescriptor(NewRuleDescriptor(name, Params{"condition": cond, "rules": describeRules(rules)}),
+		func(fs FieldState[T]) error {
+			if !active {
+				return nil
+			}
+			return applyRules(fs, rules)
+		})
+}
+
+// conditionalField validates one of two validatable entities depending on a condition.
+type conditionalField struct {
+	cond      bool
+	then      Validable
+	otherwise Validable
+}
+
+var (
+	_ Validable = (*conditionalField)(nil)
+	_ Describer = (*conditionalField)(nil)
+)
+
+// If returns a validatable entity that validates then only if cond is true. It is used for
+// fields, or whole nested schemas, that only apply in some states.
+//
+// Example:
+//
+//	schema := u.Schema{
+//		"method": u.Field(payment.Method, r.InS([]string{"card", "paypal"})),
+//		"card": u.If(payment.Method == "card", u.Schema{
+//			"lastFour": u.Field(payment.CardLastFour, r.LenS(4)),
+//			"expMonth": u.Field(payment.ExpirationMonth, r.MinN(1), r.MaxN(12)),
+//		}),
+//	}
+func If(cond bool, then Validable) Validable {
+	return &conditionalField{cond: cond, then: then}
+}
+
+// IfElse returns a validatable entity that validates then if cond is true, and otherwise if it is false.
+//
+// Example:
+//
+//	"postalCode": u.IfElse(addr.Country == "US",
+//		u.Field(addr.PostalCode, r.LenS(5)),
+//		u.Field(addr.PostalCode, r.NotZero),
+//	),
+func IfElse(cond bool, then, otherwise Validable) Validable {
+	return &conditionalField{cond: cond, then: then, otherwise: otherwise}
+}
+
+// active returns the entity that applies, or nil if there is none.
+func (f *conditionalField) active() Validable {
+	if f.cond {
+		return f.then
+	}
+	return f.otherwise
+}
+
+// Validate implements the Validable interface for conditionalField. Objects are validated
+// against the nested ValidationError of the tag, as they would be in a schema.
+func (f *conditionalField) Validate(ve *ValidationError, tag FieldTag) {
+	switch v := f.active().(type) {
+	case nil:
+	case Object:
+		v.Validate(ve.GetOrCreateNested(tag), tag)
+	default:
+		v.Validate(ve, tag)
+	}
+}
+
+// Errors implements the Validable interface for conditionalField.
+func (f *conditionalField) Errors() *ValidationError {
+	if v := f.active(); v != nil {
+		return v.Errors()
+	}
+	return nil
+}
+
+// Describe implements the Describer interface for conditionalField, describing the entity that
+// applies and the condition it depends on.
+func (f *conditionalField) Describe() FieldDescription {
+	var desc FieldDescription
+	if v := f.active(); v != nil {
+		desc = describe(v)
+	}
+	cond := f.cond
+	desc.Condition = &cond
+	return desc
+}
@@ -0,0 +1,164 @@
+package u_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestWhen(t *testing.T) {
+	tooShort := func(fs u.FieldState[string]) error {
+		if len(fs.Value) < 3 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+	noSpaces := func(fs u.FieldState[string]) error {
+		for _, c := range fs.Value {
+			if c == ' ' {
+				return errors.New("has spaces")
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		rule     u.Rule[string]
+		opts     []u.Option
+		value    string
+		expected []string
+	}{
+		{name: "When: condition met", rule: u.When(true, tooShort, noSpaces), value: "a b", expected: []string{"has spaces"}},
+		{name: "When: condition not met", rule: u.When(false, tooShort, noSpaces), value: " ", expected: nil},
+		{name: "When: reports every failure", rule: u.When(true, tooShort, noSpaces), value: " ", expected: []string{"too short", "has spaces"}},
+		{
+			name:     "When: stops at the first failure when bailing",
+			rule:     u.When(true, tooShort, noSpaces),
+			opts:     []u.Option{u.WithBail()},
+			value:    " ",
+			expected: []string{"too short"},
+		},
+		{name: "Unless: condition met", rule: u.Unless(true, tooShort), value: "a", expected: nil},
+		{name: "Unless: condition not met", rule: u.Unless(false, tooShort), value: "a", expected: []string{"too short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(u.Schema{"field": u.Field(tt.value, tt.rule)})
+
+			// Act
+			err := s.Validate(tt.opts...)
+
+			// Assert
+			var messages []string
+			var ve *u.ValidationError
+			if errors.As(err, &ve) {
+				for _, re := range ve.Errors["field"] {
+					messages = append(messages, re.Message)
+				}
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, messages)
+			}
+		})
+	}
+}
+
+func TestWhen_Descriptor(t *testing.T) {
+	// Arrange
+	rule := u.When(false, u.WithDescriptor(u.NewRuleDescriptor("string.min_length", u.Params{"min": 3}), alwaysValid))
+
+	// Act
+	desc := u.DescriptorOf(rule)
+
+	// Assert
+	if desc.Name != u.CodeWhen || desc.Params["condition"] != false {
+		t.Errorf("expected the %s descriptor with its condition, got %v", u.CodeWhen, desc)
+	}
+	rules, ok := desc.Params["rules"].([]u.RuleDescriptor)
+	if !ok || len(rules) != 1 || rules[0].Name != "string.min_length" {
+		t.Errorf("expected the descriptors of the conditional rules, got %v", desc.Params["rules"])
+	}
+	if expected := "when the condition is met: must be at least 3 characters long"; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
+
+func TestIf(t *testing.T) {
+	failing := func(u.FieldState[string]) error {
+		return errors.New("failed")
+	}
+
+	tests := []struct {
+		name     string
+		field    u.Validable
+		expected string
+	}{
+		{
+			name:     "If: condition met",
+			field:    u.If(true, u.Field("", failing)),
+			expected: `{"card":{"errors":["failed"]}}`,
+		},
+		{
+			name:     "If: condition not met",
+			field:    u.If(false, u.Field("", failing)),
+			expected: "",
+		},
+		{
+			name:     "If: nested schema",
+			field:    u.If(true, u.Schema{"lastFour": u.Field("", failing)}),
+			expected: `{"card":{"lastFour":{"errors":["failed"]}}}`,
+		},
+		{
+			name:     "IfElse: condition not met",
+			field:    u.IfElse(false, u.Field("", alwaysValid), u.Ordered(u.Entry("iban", u.Field("", failing)))),
+			expected: `{"card":{"iban":{"errors":["failed"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(u.Schema{"card": tt.field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIf_Describe(t *testing.T) {
+	// Arrange
+	schema := u.Schema{
+		"card":  u.If(true, u.Schema{"lastFour": u.Field("1234")}),
+		"phone": u.If(false, u.Field("")),
+	}
+
+	// Act
+	desc := schema.Describe()
+
+	// Assert
+	card, phone := desc.Fields[0], desc.Fields[1]
+	if card.Condition == nil || !*card.Condition || len(card.Fields) != 1 {
+		t.Errorf("expected the active schema with its condition, got %v", card)
+	}
+	if phone.Condition == nil || *phone.Condition || phone.Type != nil {
+		t.Errorf("expected an empty description with its condition, got %v", phone)
+	}
+	if expected := "card [if true]\n  lastFour (string)\nphone [if false]"; desc.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, desc.String())
+	}
+}
@@ -0,0 +1,85 @@
+package u
+
+import "maps"
+
+// DataKey identifies a value of type V in the data of a validation run, such as the current user
+// or tenant of a request. Keys are compared by identity, so each key should be created once.
+type DataKey[V any] struct {
+	name string
+}
+
+// DataSource is implemented by the states passed to rules, FieldState and SchemaState, to read
+// the data of their validation run with DataKey.Get.
+type DataSource interface {
+	validationRun() *run
+}
+
+var (
+	_ DataSource = FieldState[any]{}
+	_ DataSource = SchemaState[any]{}
+)
+
+// NewDataKey returns a key for values of type V in the data of a validation run. The name is
+// only used to describe the key.
+//
+// Example:
+//
+//	var CurrentUser = u.NewDataKey[User]("currentUser")
+func NewDataKey[V any](name string) *DataKey[V] {
+	return &DataKey[V]{name: name}
+}
+
+// String returns the name of the key.
+func (k *DataKey[V]) String() string {
+	return k.name
+}
+
+// Get returns the value of the key in the data of the validation run of a rule, and whether
+// it was given with WithData.
+//
+// Example:
+//
+//	func OwnedByCurrentUser(fs u.FieldState[Document]) error {
+//		user, ok := CurrentUser.Get(fs)
+//		if !ok || fs.Value.OwnerID != user.ID {
+//			return errors.New("must be owned by the current user")
+//		}
+//		return nil
+//	}
+func (k *DataKey[V]) Get(src DataSource) (V, bool) {
+	var zero V
+	r := src.validationRun()
+	if r == nil {
+		return zero, false
+	}
+
+	value, ok := r.data[k].(V)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+// WithData gives value to the rules of the validation run under key, see DataKey.Get.
+//
+// Example:
+//
+//	err := s.Validate(u.WithData(CurrentUser, user), u.WithData(Tenant, tenant))
+func WithData[V any](key *DataKey[V], value V) Option {
+	return func(o *options) {
+		data := make(map[any]any, len(o.data)+1)
+		maps.Copy(data, o.data)
+		data[key] = value
+		o.data = data
+	}
+}
+
+// validationRun implements the DataSource interface for FieldState.
+func (fs FieldState[T]) validationRun() *run {
+	return fs.run
+}
+
+// validationRun implements the DataSource interface for SchemaState.
+func (ss SchemaState[T]) validationRun() *run {
+	return ss.run
+}
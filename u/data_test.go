@@ -0,0 +1,102 @@
+package u_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+type tenant struct {
+	ID       string
+	MaxSeats int
+}
+
+var currentTenant = u.NewDataKey[tenant]("tenant")
+
+func withinSeats(fs u.FieldState[int]) error {
+	t, ok := currentTenant.Get(fs)
+	if !ok {
+		return errors.New("no tenant")
+	}
+	if fs.Value > t.MaxSeats {
+		return errors.New("exceeds the seats of the tenant")
+	}
+	return nil
+}
+
+func TestDataKey_Get(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []u.Option
+		expected string
+	}{
+		{
+			name: "data of the run",
+			opts: []u.Option{u.WithData(currentTenant, tenant{ID: "acme", MaxSeats: 10})},
+		},
+		{
+			name:     "data failing the rule",
+			opts:     []u.Option{u.WithData(currentTenant, tenant{ID: "acme", MaxSeats: 2})},
+			expected: `{"seats":{"errors":["exceeds the seats of the tenant"]}}`,
+		},
+		{
+			name:     "missing data",
+			expected: `{"seats":{"errors":["no tenant"]}}`,
+		},
+		{
+			name:     "data of another key",
+			opts:     []u.Option{u.WithData(u.NewDataKey[tenant]("tenant"), tenant{MaxSeats: 10})},
+			expected: `{"seats":{"errors":["no tenant"]}}`,
+		},
+		{
+			name: "last value of a key",
+			opts: []u.Option{
+				u.WithData(currentTenant, tenant{MaxSeats: 2}),
+				u.WithData(currentTenant, tenant{MaxSeats: 10}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(u.Schema{"seats": u.Field(5, withinSeats)})
+
+			// Act
+			err := s.Validate(tt.opts...)
+
+			// Assert
+			if got := messagesOf(asValidationError(t, err)); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDataKey_Get_SchemaState(t *testing.T) {
+	// Arrange
+	var got tenant
+	schema := u.WithRules(u.Schema{}, 0, func(ss u.SchemaState[int]) error {
+		got, _ = currentTenant.Get(ss)
+		return nil
+	})
+
+	// Act
+	_ = u.NewSouuup(schema).Validate(u.WithData(currentTenant, tenant{ID: "acme"}))
+
+	// Assert
+	if got.ID != "acme" {
+		t.Errorf("expected the tenant of the run, got %+v", got)
+	}
+}
+
+func TestDataKey_Get_OutsideOfRun(t *testing.T) {
+	// Act
+	_, ok := currentTenant.Get(u.FieldState[int]{Value: 1})
+
+	// Assert
+	if ok {
+		t.Error("expected no data outside of a validation run")
+	}
+}
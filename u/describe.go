@@ -0,0 +1,344 @@
+package u
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RuleDescriptor describes what a rule checks, so that schemas can be documented and exported
+// to other formats such as JSON Schema.
+type RuleDescriptor struct {
+	// Name identifies the rule. Built-in rules use the code of the error they report,
+	// e.g. "string.min_length". Rules without a descriptor are named after their function.
+	Name string `json:"name"`
+
+	// Params are the parameters of the rule, e.g. {"min": 3}. Rules wrapping other rules,
+	// such as r.Every, have the descriptor of the wrapped rule as their "rule" param.
+	Params Params `json:"params,omitempty"`
+
+	// Description is a human-readable description of the rule, e.g. "must be at least 3 characters long".
+	// It is empty for rules without a registered description.
+	Description string `json:"description,omitempty"`
+
+	// Opaque is true for rules without a descriptor, which can only be identified by name.
+	Opaque bool `json:"opaque,omitempty"`
+}
+
+// FieldDescription describes a field of a schema, or a schema itself, with its rules.
+type FieldDescription struct {
+	// Tag is the tag of the field in its schema. It is empty for the root of a description.
+	Tag FieldTag `json:"tag,omitempty"`
+
+	// Type is the type of the value of a field. It is nil for schemas.
+	Type reflect.Type `json:"-"`
+
+	// Rules describe the rules of the field, in the order they are validated.
+	Rules []RuleDescriptor `json:"rules,omitempty"`
+
+	// Fields describe the nested fields of a schema, or of a field with a nested schema.
+	Fields []FieldDescription `json:"fields,omitempty"`
+
+	// Condition is set for fields that are only validated in some states, see If, and reports
+	// whether the condition held. The rest of the description is that of the entity that applies.
+	Condition *bool `json:"condition,omitempty"`
+}
+
+// NewRuleDescriptor creates a RuleDescriptor with the given name and params, rendering its
+// description from the template registered in the DefaultCatalog under DescriptionKey(name),
+// in the DefaultLocale. The description is left empty if there is no such template.
+//
+// Example:
+//
+//	u.DefaultCatalog.MustRegister("en", u.DescriptionKey("myapp.min_words"), "must have at least {{.min}} words")
+//
+//	desc := u.NewRuleDescriptor("myapp.min_words", u.Params{"min": n})
+func NewRuleDescriptor(name string, params Params) RuleDescriptor {
+	desc := RuleDescriptor{Name: name, Params: params}
+	if msg, ok := DefaultCatalog.Translate(DefaultLocale, RuleError{Code: DescriptionKey(name), Params: params}); ok {
+		desc.Description = msg
+	}
+	return desc
+}
+
+// DescriptionKey returns the catalog key of the description of a rule. Descriptions are
+// registered in a Catalog like error messages, and rendered with the rule params.
+func DescriptionKey(name string) string {
+	return name + ".description"
+}
+
+// Describer is implemented by validatable entities that can describe their fields and rules.
+// FieldDef, Schema and OrderedSchema implement this interface.
+type Describer interface {
+	// Describe returns the description of the entity.
+	Describe() FieldDescription
+}
+
+// describedRules holds the code pointers of the rules returned by WithDescriptor, which are
+// the only rules that are called to retrieve their descriptor.
+var describedRules sync.Map
+
+// namedDescriptors holds the descriptors of plain functions registered with RegisterDescriptor,
+// keyed by function name.
+var namedDescriptors sync.Map
+
+// WithDescriptor returns a rule that validates values with rule, and that is described by desc.
+// Rules returned by constructors, such as r.MinS(3), use it to publish their parameters.
+//
+// Example:
+//
+//	func MinWords(n int) u.StringRule {
+//		return u.WithDescriptor(u.RuleDescriptor{Name: "myapp.min_words", Params: u.Params{"min": n}},
+//			func(fs u.FieldState[string]) error {
+//				...
+//			})
+//	}
+func WithDescriptor[T any](desc RuleDescriptor, rule Rule[T]) Rule[T] {
+	described := func(fs FieldState[T]) error {
+		if fs.describe != nil {
+			*fs.describe = desc
+			return nil
+		}
+		return rule(fs)
+	}
+
+	ptr := reflect.ValueOf(described).Pointer()
+	if _, ok := describedRules.Load(ptr); !ok {
+		describedRules.Store(ptr, struct{}{})
+	}
+	return described
+}
+
+// RegisterDescriptor registers desc as the descriptor of a rule declared as a function, such as
+// r.NotZero. Generic functions are registered for all their instantiations. It returns true so
+// it can be called in package-level variable declarations.
+//
+// Example:
+//
+//	var _ = u.RegisterDescriptor(ValidEmail, u.RuleDescriptor{Name: "myapp.email"})
+func RegisterDescriptor(fn any, desc RuleDescriptor) bool {
+	namedDescriptors.Store(funcName(reflect.ValueOf(fn).Pointer()), desc)
+	return true
+}
+
+// DescriptorOf returns the descriptor of a rule. Rules without a descriptor are described
+// as opaque rules named after their function.
+func DescriptorOf[T any](rule Rule[T]) RuleDescriptor {
+	ptr := reflect.ValueOf(rule).Pointer()
+
+	if _, ok := describedRules.Load(ptr); ok {
+		var desc RuleDescriptor
+		_ = rule(FieldState[T]{describe: &desc})
+		return desc
+	}
+
+	return namedDescriptor(ptr)
+}
+
+// namedDescriptor returns the descriptor registered for the function at a code pointer, or
+// an opaque descriptor named after the function if there is none.
+func namedDescriptor(ptr uintptr) RuleDescriptor {
+	name := funcName(ptr)
+	if desc, ok := namedDescriptors.Load(name); ok {
+		return desc.(RuleDescriptor) //nolint:forcetypeassert // only RuleDescriptor values are stored
+	}
+	return RuleDescriptor{Name: name, Opaque: true}
+}
+
+// funcName returns the name of the function at a code pointer, without the type arguments
+// of generic functions.
+func funcName(ptr uintptr) string {
+	fn := runtime.FuncForPC(ptr)
+	if fn == nil {
+		return ""
+	}
+	return strings.ReplaceAll(fn.Name(), "[...]", "")
+}
+
+// Describe implements the Describer interface for FieldDef, describing the type of its value and its rules.
+func (f *FieldDef[T]) Describe() FieldDescription {
+	rules := make([]RuleDescriptor, len(f.rules))
+	for i, rule := range f.rules {
+		rules[i] = DescriptorOf(rule)
+	}
+	return FieldDescription{Type: reflect.TypeFor[T](), Rules: rules}
+}
+
+// Describe implements the Describer interface for Schema, describing its fields sorted by tag.
+func (s Schema) Describe() FieldDescription {
+	return FieldDescription{Fields: describeEntries(s.Entries())}
+}
+
+// Describe implements the Describer interface for OrderedSchema, describing its fields in declaration order.
+func (s OrderedSchema) Describe() FieldDescription {
+	return FieldDescription{Fields: describeEntries(s.Entries())}
+}
+
+// Describe implements the Describer interface for eachSchema, describing a slice of T whose only
+// field is the description of its elements, tagged "0". Elements are described by the entity
+// validating the zero value of T, so the description does not depend on the items.
+func (s *eachSchema[T]) Describe() FieldDescription {
+	var zero T
+	elem := describe(s.elem(zero))
+	elem.Tag = "0"
+	return FieldDescription{Type: reflect.TypeFor[[]T](), Fields: []FieldDescription{elem}}
+}
+
+// Describe implements the Describer interface for nestedField, describing the field and the
+// fields of its nested object.
+func (f *nestedField) Describe() FieldDescription {
+	desc := describe(f.field)
+	desc.Fields = describe(f.nested).Fields
+	return desc
+}
+
+// Translate returns a copy of the description where the description of every rule in the tree
+// is translated to the given locale. Rules the translator has no description for keep their
+// current description.
+//
+// Example:
+//
+//	desc := schema.Describe().Translate(u.DefaultCatalog, "es")
+func (d FieldDescription) Translate(t Translator, locale Locale) FieldDescription {
+	if d.Rules != nil {
+		rules := make([]RuleDescriptor, len(d.Rules))
+		for i, rule := range d.Rules {
+			rules[i] = translateDescriptor(t, locale, rule)
+		}
+		d.Rules = rules
+	}
+	if d.Fields != nil {
+		fields := make([]FieldDescription, len(d.Fields))
+		for i, field := range d.Fields {
+			fields[i] = field.Translate(t, locale)
+		}
+		d.Fields = fields
+	}
+	return d
+}
+
+// translateDescriptor translates the description of a rule, and of any rules in its params.
+func translateDescriptor(t Translator, locale Locale, desc RuleDescriptor) RuleDescriptor {
+	if len(desc.Params) > 0 {
+		params := make(Params, len(desc.Params))
+		for k, v := range desc.Params {
+			switch v := v.(type) {
+			case RuleDescriptor:
+				params[k] = translateDescriptor(t, locale, v)
+			case []RuleDescriptor:
+				translated := make([]RuleDescriptor, len(v))
+				for i, child := range v {
+					translated[i] = translateDescriptor(t, locale, child)
+				}
+				params[k] = translated
+			default:
+				params[k] = v
+			}
+		}
+		desc.Params = params
+	}
+
+	if msg, ok := t.Translate(locale, RuleError{Code: DescriptionKey(desc.Name), Params: desc.Params}); ok {
+		desc.Description = msg
+	}
+	return desc
+}
+
+// MarshalJSON implements the json.Marshaler interface for FieldDescription, writing the
+// type of the field as its name.
+func (d FieldDescription) MarshalJSON() ([]byte, error) {
+	obj := newOrderedObject()
+	if d.Tag != "" {
+		obj.set("tag", d.Tag)
+	}
+	if d.Type != nil {
+		obj.set("type", d.Type.String())
+	}
+	if len(d.Rules) > 0 {
+		obj.set("rules", d.Rules)
+	}
+	if d.Condition != nil {
+		obj.set("condition", *d.Condition)
+	}
+	if len(d.Fields) > 0 {
+		obj.set("fields", d.Fields)
+	}
+	return json.Marshal(obj)
+}
+
+// String returns the description as an indented tree with a line per field and rule,
+// suitable for documentation.
+//
+// Example output:
+//
+//	address
+//	  city (string)
+//	    - is required
+//	username (string)
+//	  - must be at least 3 characters long
+func (d FieldDescription) String() string {
+	var sb strings.Builder
+	if d.Tag == "" && d.Type == nil {
+		// The root of a schema description only lists its rules and fields
+		for _, rule := range d.Rules {
+			sb.WriteString("- " + rule.text() + "\n")
+		}
+		for _, field := range d.Fields {
+			field.write(&sb, 0)
+		}
+	} else {
+		d.write(&sb, 0)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// write writes the description of a field and its nested fields at the given depth.
+func (d FieldDescription) write(sb *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	sb.WriteString(indent + d.Tag)
+	if d.Type != nil {
+		sb.WriteString(" (" + d.Type.String() + ")")
+	}
+	if d.Condition != nil {
+		sb.WriteString(" [if " + strconv.FormatBool(*d.Condition) + "]")
+	}
+	sb.WriteString("\n")
+
+	for _, rule := range d.Rules {
+		sb.WriteString(indent + "  - " + rule.text() + "\n")
+	}
+	for _, field := range d.Fields {
+		field.write(sb, depth+1)
+	}
+}
+
+// text returns the description of the rule, or its name if it has none.
+func (d RuleDescriptor) text() string {
+	if d.Description != "" {
+		return d.Description
+	}
+	return d.Name
+}
+
+// describeEntries describes the entries of an object.
+func describeEntries(entries []SchemaEntry) []FieldDescription {
+	fields := make([]FieldDescription, len(entries))
+	for i, entry := range entries {
+		fields[i] = describe(entry.Field)
+		fields[i].Tag = entry.Tag
+	}
+	return fields
+}
+
+// describe describes a validatable entity, which is described as an empty field if it does
+// not implement Describer.
+func describe(v Validable) FieldDescription {
+	if d, ok := v.(Describer); ok {
+		return d.Describe()
+	}
+	return FieldDescription{}
+}
@@ -0,0 +1,251 @@
+package u_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func isEven(fs u.FieldState[int]) error {
+	if fs.Value%2 != 0 {
+		return errors.New("must be even")
+	}
+	return nil
+}
+
+var _ = u.RegisterDescriptor(isEven, u.RuleDescriptor{Name: "test.even"})
+
+func TestDescriptorOf(t *testing.T) {
+	t.Run("returns the descriptor of described rules without validating", func(t *testing.T) {
+		// Arrange
+		called := false
+		rule := u.WithDescriptor(u.RuleDescriptor{Name: "test.min", Params: u.Params{"min": 3}},
+			func(u.FieldState[string]) error {
+				called = true
+				return nil
+			})
+
+		// Act
+		desc := u.DescriptorOf(rule)
+
+		// Assert
+		expected := u.RuleDescriptor{Name: "test.min", Params: u.Params{"min": 3}}
+		if !reflect.DeepEqual(desc, expected) {
+			t.Errorf("expected %v, got %v", expected, desc)
+		}
+		if called {
+			t.Error("expected the rule not to be called")
+		}
+	})
+
+	t.Run("described rules still validate", func(t *testing.T) {
+		// Arrange
+		rule := u.WithDescriptor(u.RuleDescriptor{Name: "test.fail"}, func(u.FieldState[string]) error {
+			return errors.New("failed")
+		})
+
+		// Act
+		err := u.NewSouuup(u.Schema{"field": u.Field("value", rule)}).Validate()
+
+		// Assert
+		if err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+
+	t.Run("returns the descriptor of registered functions", func(t *testing.T) {
+		// Act
+		desc := u.DescriptorOf(isEven)
+
+		// Assert
+		if desc.Name != "test.even" || desc.Opaque {
+			t.Errorf("expected the registered descriptor, got %v", desc)
+		}
+	})
+
+	t.Run("describes other rules as opaque without calling them", func(t *testing.T) {
+		// Arrange
+		called := false
+		rule := func(u.FieldState[int]) error {
+			called = true
+			return nil
+		}
+
+		// Act
+		desc := u.DescriptorOf(rule)
+
+		// Assert
+		if !desc.Opaque || desc.Name == "" {
+			t.Errorf("expected an opaque descriptor with the function name, got %v", desc)
+		}
+		if called {
+			t.Error("expected the rule not to be called")
+		}
+	})
+}
+
+func TestSchema_Describe(t *testing.T) {
+	// Arrange
+	schema := u.Schema{
+		"name": u.Field("john", alwaysValid),
+		"address": u.Ordered(
+			u.Entry("street", u.Field("Main St")),
+			u.Entry("number", u.Field(3, isEven)),
+		),
+		"mock": &mockValidable{},
+	}
+
+	// Act
+	desc := schema.Describe()
+
+	// Assert
+	if desc.Type != nil {
+		t.Errorf("expected no type for a schema, got %v", desc.Type)
+	}
+
+	tags := make([]string, len(desc.Fields))
+	for i, field := range desc.Fields {
+		tags[i] = field.Tag
+	}
+	if !reflect.DeepEqual(tags, []string{"address", "mock", "name"}) {
+		t.Fatalf("expected fields sorted by tag, got %v", tags)
+	}
+
+	address := desc.Fields[0]
+	if address.Fields[0].Tag != "street" || address.Fields[1].Tag != "number" {
+		t.Errorf("expected ordered schema fields in declaration order, got %v", address.Fields)
+	}
+	number := address.Fields[1]
+	if number.Type != reflect.TypeFor[int]() || len(number.Rules) != 1 || number.Rules[0].Name != "test.even" {
+		t.Errorf("expected an int field with the test.even rule, got %v", number)
+	}
+	if desc.Fields[1].Type != nil || desc.Fields[1].Fields != nil {
+		t.Errorf("expected an empty description for a validable that is not a Describer, got %v", desc.Fields[1])
+	}
+}
+
+func alwaysValid(u.FieldState[string]) error {
+	return nil
+}
+
+func TestNewRuleDescriptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		params   u.Params
+		expected string
+	}{
+		{
+			name:     "renders the description with the params",
+			rule:     "string.min_length",
+			params:   u.Params{"min": 3},
+			expected: "must be at least 3 characters long",
+		},
+		{
+			name:     "renders nested rule descriptors",
+			rule:     "slice.every",
+			params:   u.Params{"rule": u.NewRuleDescriptor("string.max_length", u.Params{"max": 1})},
+			expected: "every element must be at most 1 character long",
+		},
+		{
+			name:     "leaves the description empty for unknown rules",
+			rule:     "test.unknown",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			desc := u.NewRuleDescriptor(tt.rule, tt.params)
+
+			// Assert
+			if desc.Name != tt.rule {
+				t.Errorf("expected name %q, got %q", tt.rule, desc.Name)
+			}
+			if desc.Description != tt.expected {
+				t.Errorf("expected description %q, got %q", tt.expected, desc.Description)
+			}
+		})
+	}
+}
+
+func TestFieldDescription_Translate(t *testing.T) {
+	// Arrange
+	desc := u.Schema{
+		"tags": u.Field([]string{}, u.WithDescriptor(
+			u.NewRuleDescriptor("slice.every", u.Params{"rule": u.NewRuleDescriptor("string.min_length", u.Params{"min": 2})}),
+			func(u.FieldState[[]string]) error { return nil },
+		)),
+	}.Describe()
+
+	// Act
+	translated := desc.Translate(u.DefaultCatalog, "es")
+
+	// Assert
+	rule := translated.Fields[0].Rules[0]
+	if expected := "cada elemento debe tener al menos 2 caracteres"; rule.Description != expected {
+		t.Errorf("expected %q, got %q", expected, rule.Description)
+	}
+	inner, ok := rule.Params["rule"].(u.RuleDescriptor)
+	if !ok || inner.Description != "debe tener al menos 2 caracteres" {
+		t.Errorf("expected the nested descriptor to be translated, got %v", rule.Params["rule"])
+	}
+	if original := desc.Fields[0].Rules[0].Description; original != "every element must be at least 2 characters long" {
+		t.Errorf("expected the original description to be unchanged, got %q", original)
+	}
+}
+
+func TestFieldDescription_MarshalJSON(t *testing.T) {
+	// Arrange
+	desc := u.Ordered(
+		u.Entry("name", u.Field("john", u.WithDescriptor(
+			u.NewRuleDescriptor("string.min_length", u.Params{"min": 3}),
+			alwaysValid,
+		))),
+		u.Entry("address", u.Ordered(u.Entry("city", u.Field("London")))),
+	).Describe()
+
+	// Act
+	out, err := json.Marshal(desc)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"fields":[` +
+		`{"tag":"name","type":"string","rules":[{"name":"string.min_length","params":{"min":3},"description":"must be at least 3 characters long"}]},` +
+		`{"tag":"address","fields":[{"tag":"city","type":"string"}]}]}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestFieldDescription_String(t *testing.T) {
+	// Arrange
+	desc := u.Schema{
+		"username": u.Field("john", u.WithDescriptor(
+			u.NewRuleDescriptor("string.min_length", u.Params{"min": 3}),
+			alwaysValid,
+		)),
+		"address": u.Schema{
+			"city": u.Field("London", u.WithDescriptor(u.RuleDescriptor{Name: "test.city"}, alwaysValid)),
+		},
+	}.Describe()
+
+	// Act
+	out := desc.String()
+
+	// Assert
+	expected := "address\n" +
+		"  city (string)\n" +
+		"    - test.city\n" +
+		"username (string)\n" +
+		"  - must be at least 3 characters long"
+	if out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
@@ -1,18 +1,103 @@
 package u
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 )
 
+// Params holds the parameters of a rule failure, such as the configured limits
+// and the offending value. Keys are stable and part of the rule's contract.
+type Params = map[string]any
+
 // RuleError represents a single validation rule failure.
-// It implements the error interface and contains the error message.
-type RuleError string
+// It carries a stable machine-readable code, the parameters of the rule that failed
+// and the rendered human-readable message, allowing clients to branch on and localise
+// failures without parsing the message.
+type RuleError struct {
+	// Code is a stable identifier for the failure, e.g. "string.min_length".
+	// It is empty for errors returned by rules that do not provide one.
+	Code string `json:"code,omitempty"`
+
+	// Params holds the rule parameters, e.g. {"min": 3, "actual": 2}.
+	Params Params `json:"params,omitempty"`
+
+	// Message is the rendered, human-readable error message.
+	Message string `json:"message"`
+
+	// Severity is the severity of the failure. It is empty for failures of rules that do not
+	// set one, which fail validation like SeverityError.
+	Severity Severity `json:"severity,omitempty"`
+}
 
 // Error returns the error message for a rule validation failure.
 // This implementation satisfies the error interface.
 func (re RuleError) Error() string {
-	return string(re)
+	return re.Message
+}
+
+// NewRuleError creates a RuleError with the given code and params, rendering its message
+// from the DefaultCatalog in the DefaultLocale. If the catalog has no message for the code,
+// the code itself is used as the message.
+//
+// Example:
+//
+//	u.DefaultCatalog.MustRegister("en", "string.email", "{{printf \"%q\" .actual}} is not a valid email")
+//
+//	return u.NewRuleError("string.email", u.Params{"actual": fs.Value})
+func NewRuleError(code string, params Params) RuleError {
+	re := RuleError{Code: code, Params: params, Message: code}
+	if msg, ok := DefaultCatalog.Translate(DefaultLocale, re); ok {
+		re.Message = msg
+	}
+	return re
+}
+
+// Errorf creates a RuleError with the given code and params, formatting the message
+// according to a format specifier.
+//
+// Example:
+//
+//	return u.Errorf("string.email", u.Params{"actual": fs.Value}, "%q is not a valid email", fs.Value)
+func Errorf(code string, params Params, format string, args ...any) RuleError {
+	return RuleError{
+		Code:    code,
+		Params:  params,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// AsRuleError converts any error into a RuleError. If err is, or wraps, a RuleError its code
+// and params are kept, otherwise the returned RuleError only carries the error message.
+func AsRuleError(err error) RuleError {
+	var re RuleError
+	if errors.As(err, &re) {
+		re.Message = err.Error()
+		return re
+	}
+	return RuleError{Message: err.Error()}
+}
+
+// ElementError is a rule failure for a single element of a collection. It is used as a rule
+// parameter by rules that validate every element of a slice.
+type ElementError struct {
+	Index int       `json:"index"`
+	Error RuleError `json:"error"`
+}
+
+// BranchError is the failure of one of the rules combined by a logical rule, such as r.Or. It
+// is used as a rule parameter to explain which of the rules failed.
+type BranchError struct {
+	// Index is the position of the rule in the combined rules.
+	Index int `json:"index"`
+
+	// Rule is the name of the descriptor of the rule, see DescriptorOf.
+	Rule string `json:"rule"`
+
+	// Error is the failure of the rule.
+	Error RuleError `json:"error"`
 }
 
 // RuleErrors represents a slice of rule validation failures for a single field.
@@ -38,6 +123,24 @@ type ValidationError struct {
 
 	// Parent points to the parent ValidationError in the tree, if any
 	Parent *ValidationError
+
+	// tag is the tag this ValidationError is nested under in its Parent
+	tag FieldTag
+
+	// order is the declaration order of the fields at this level
+	order []FieldTag
+
+	// declared indexes the tags of order
+	declared map[FieldTag]struct{}
+
+	// notices contains the failures at the current level that do not fail validation, such as warnings
+	notices FieldsErrorMap
+
+	// value is the value bound to this level with Bind, if any
+	value any
+
+	// run is the validation run the tree is being built by, if any
+	run *run
 }
 
 // ToMapResult is the type returned by ValidationError.ToMap().
@@ -55,8 +158,77 @@ func NewValidationError() *ValidationError {
 
 // AddError adds a validation error for a specific field tag.
 // The error is converted to a RuleError and appended to any existing errors for that field.
+// If err is, or wraps, a *ValidationError, such as the errors of the values of a map, its
+// errors are merged into the nested errors of the field instead. Failures of rules combining
+// other rules, such as When, are added as errors of their own. Failures with a severity that
+// does not fail validation, such as SeverityWarning, are kept apart from the errors, see Result.
+// During a validation run limited with WithMaxErrors, errors past the limit are discarded.
 func (ve *ValidationError) AddError(tag FieldTag, err error) {
-	ve.Errors[tag] = append(ve.Errors[tag], RuleError(err.Error()))
+	if errs, ok := err.(ruleErrors); ok { //nolint:errorlint // only the errors combined by applyRules are split
+		for _, err := range errs {
+			ve.AddError(tag, err)
+		}
+		return
+	}
+
+	var nested *ValidationError
+	if errors.As(err, &nested) {
+		ve.GetOrCreateNested(tag).merge(nested)
+		return
+	}
+
+	re := AsRuleError(err)
+
+	ve.run.lock()
+	defer ve.run.unlock()
+
+	if !re.Severity.fails() {
+		if ve.notices == nil {
+			ve.notices = make(FieldsErrorMap)
+		}
+		ve.notices[tag] = append(ve.notices[tag], re)
+		return
+	}
+
+	if ve.run != nil {
+		if ve.run.limitReached() {
+			return
+		}
+		ve.run.errorCount++
+	}
+	ve.Errors[tag] = append(ve.Errors[tag], re)
+}
+
+// merge adds the errors of other, and of its nested errors, to ve.
+func (ve *ValidationError) merge(other *ValidationError) {
+	for _, tag := range other.tags() {
+		for _, err := range other.Errors[tag] {
+			ve.AddError(tag, err)
+		}
+		if nested, ok := other.NestedErrors[tag]; ok && nested.HasErrors() {
+			ve.GetOrCreateNested(tag).merge(nested)
+		}
+	}
+}
+
+// truncate keeps the first n errors of the tree in reporting order, discarding the others, and
+// returns how many more errors could have been kept.
+func (ve *ValidationError) truncate(n int) int {
+	for _, tag := range ve.tags() {
+		if errs := ve.Errors[tag]; len(errs) > 0 {
+			kept := min(n, len(errs))
+			if kept == 0 {
+				delete(ve.Errors, tag)
+			} else {
+				ve.Errors[tag] = errs[:kept]
+			}
+			n -= kept
+		}
+		if nested, ok := ve.NestedErrors[tag]; ok {
+			n = nested.truncate(n)
+		}
+	}
+	return n
 }
 
 // HasErrors returns true if there are any validation errors at any level in the tree.
@@ -85,87 +257,232 @@ func (ve *ValidationError) HasErrors() bool {
 //
 //	{
 //	  "username": {
-//	    "errors": ["length is 2, but needs to be at least 3"]
+//	    "errors": [{"code": "string.min_length", "params": {"min": 3, "actual": 2}, "message": "..."}]
 //	  },
 //	  "address": {
 //	    "city": {
-//	      "errors": ["cannot be empty"]
+//	      "errors": [{"code": "value.not_zero", "message": "..."}]
 //	    }
 //	  }
 //	}
 func (ve *ValidationError) ToMap() ToMapResult {
+	obj := ve.toObject(func(errs RuleErrors) any { return errs })
+	if obj == nil {
+		return nil
+	}
+
+	result := make(ToMapResult, len(obj.keys))
+	for _, key := range obj.keys {
+		result[key] = obj.values[key].(*orderedObject).toMap() //nolint:forcetypeassert // built by toObject
+	}
+	return result
+}
+
+// toObject builds the ordered object representation of the error tree, using render to
+// produce the value stored under each "errors" key. Fields are ordered as returned by tags.
+func (ve *ValidationError) toObject(render func(RuleErrors) any) *orderedObject {
 	if !ve.HasErrors() {
 		return nil
 	}
 
-	result := make(ToMapResult, len(ve.Errors)+len(ve.NestedErrors))
+	result := newOrderedObject()
+	for _, tag := range ve.tags() {
+		entry := newOrderedObject()
 
-	// Add direct field errors
-	for field, errors := range ve.Errors {
-		result[field] = map[string]any{
-			"errors": errors,
+		// Add direct field errors
+		if errs := ve.Errors[tag]; len(errs) > 0 {
+			entry.set("errors", render(errs))
 		}
-	}
 
-	// Add nested field errors
-	for nestedField, nestedErr := range ve.NestedErrors {
-		if nestedErr.HasErrors() {
-			// recursive
-			nestedMap := nestedErr.ToMap()
-
-			// Check for existing entry.
-			if existing, exists := result[nestedField]; exists {
-				// If entry exists as a map (from direct errors), merge the nested data
-				// the merge is between keys. same keys will be will replaced!
-				// Manually copy each key-value pair because maps.Copy isn't type smart enough
-				for k, v := range nestedMap {
-					existing[k] = v
-				}
-			} else {
-				// No existing entry, add the nested map directly
-				// Copy all nested values since the type inference isn't smart enough to directly assign nestedMap
-				result[nestedField] = make(map[FieldTag]any)
-				for k, v := range nestedMap {
-					result[nestedField][k] = v
-				}
+		// Add nested field errors. The merge is between keys, same keys will be replaced!
+		if nestedErr := ve.NestedErrors[tag]; nestedErr != nil && nestedErr.HasErrors() {
+			nested := nestedErr.toObject(render)
+			for _, key := range nested.keys {
+				entry.set(key, nested.values[key])
 			}
 		}
+
+		result.set(tag, entry)
 	}
 
 	return result
 }
 
+// declare records tags as the order in which the fields of this level are reported.
+// Schemas declare their fields before validating them, so the order of the errors does
+// not depend on the order in which they were added.
+func (ve *ValidationError) declare(tags []FieldTag) {
+	ve.run.lock()
+	defer ve.run.unlock()
+
+	if ve.declared == nil {
+		ve.declared = make(map[FieldTag]struct{}, len(tags))
+	}
+	for _, tag := range tags {
+		if _, ok := ve.declared[tag]; !ok {
+			ve.declared[tag] = struct{}{}
+			ve.order = append(ve.order, tag)
+		}
+	}
+}
+
+// declareFirst records tag as the first field of this level, if it is not declared yet.
+func (ve *ValidationError) declareFirst(tag FieldTag) {
+	if _, ok := ve.declared[tag]; ok {
+		return
+	}
+	ve.declare([]FieldTag{tag})
+	ve.order = append([]FieldTag{tag}, ve.order[:len(ve.order)-1]...)
+}
+
+// tags returns the tags with errors at this level in reporting order: declared tags first,
+// in declaration order, followed by any other tags in lexical order.
+func (ve *ValidationError) tags() []FieldTag {
+	hasErrors := func(tag FieldTag) bool {
+		if len(ve.Errors[tag]) > 0 {
+			return true
+		}
+		nested, ok := ve.NestedErrors[tag]
+		return ok && nested.HasErrors()
+	}
+
+	tags := make([]FieldTag, 0, len(ve.Errors)+len(ve.NestedErrors))
+	for _, tag := range ve.order {
+		if hasErrors(tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	var undeclared []FieldTag
+	for tag := range ve.Errors {
+		if _, ok := ve.declared[tag]; !ok && hasErrors(tag) {
+			undeclared = append(undeclared, tag)
+		}
+	}
+	for tag := range ve.NestedErrors {
+		_, declared := ve.declared[tag]
+		_, listed := ve.Errors[tag] // checked by the loop above
+		if !declared && !listed && hasErrors(tag) {
+			undeclared = append(undeclared, tag)
+		}
+	}
+	slices.Sort(undeclared)
+
+	return append(tags, undeclared...)
+}
+
 // MarshalJSON implements the json.Marshaler interface for ValidationError.
-// It creates a JSON representation of the validation errors using the ToMap method.
+// It creates a JSON representation of the validation errors with the same shape as ToMap,
+// so every error is output with its code, params and message. Fields are output in the
+// order they were declared in their schema, making the output stable across runs.
 func (ve *ValidationError) MarshalJSON() ([]byte, error) {
-	errorMap := ve.ToMap()
-	if errorMap == nil {
+	obj := ve.toObject(func(errs RuleErrors) any { return errs })
+	if obj == nil {
 		return []byte("null"), nil
 	}
 
-	return json.Marshal(errorMap)
+	return json.Marshal(obj)
 }
 
 // GetOrCreateNested returns a nested ValidationError for a field, creating it if necessary.
 // This is used when building up validation errors for nested structures.
 func (ve *ValidationError) GetOrCreateNested(tag FieldTag) *ValidationError {
+	ve.run.lock()
+	defer ve.run.unlock()
+
 	if _, exists := ve.NestedErrors[tag]; !exists {
-		ve.NestedErrors[tag] = NewValidationError()
+		ve.NestedErrors[tag] = ve.newNested(tag)
 	}
 	return ve.NestedErrors[tag]
 }
 
-// Error returns a JSON string representation of the validation errors.
+// newNested creates a ValidationError to be nested under tag, linked to ve as its parent.
+func (ve *ValidationError) newNested(tag FieldTag) *ValidationError {
+	nested := NewValidationError()
+	nested.Parent = ve
+	nested.tag = tag
+	nested.run = ve.run
+	return nested
+}
+
+// Error returns a JSON string representation of the validation errors, containing
+// only the error messages. Like MarshalJSON, fields are output in declaration order.
 // This implementation satisfies the error interface.
 func (ve *ValidationError) Error() string {
 	if !ve.HasErrors() {
 		return ""
 	}
 
-	bytes, err := json.Marshal(ve)
+	bytes, err := json.Marshal(ve.toObject(messages))
 	if err != nil {
 		fmt.Printf("marshalling error %s", err.Error())
 	}
 
 	return string(bytes)
 }
+
+// messages renders rule errors as a list of their messages.
+func messages(errs RuleErrors) any {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Message
+	}
+	return msgs
+}
+
+// orderedObject is a JSON object that keeps the insertion order of its keys.
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// newOrderedObject creates an empty orderedObject.
+func newOrderedObject() *orderedObject {
+	return &orderedObject{values: make(map[string]any)}
+}
+
+// set sets the value of a key, appending the key if it is new.
+func (o *orderedObject) set(key string, value any) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// toMap converts the object, and any nested objects, to maps.
+func (o *orderedObject) toMap() map[string]any {
+	result := make(map[string]any, len(o.keys))
+	for key, value := range o.values {
+		if nested, ok := value.(*orderedObject); ok {
+			value = nested.toMap()
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, writing keys in insertion order.
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valueBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -3,6 +3,7 @@ package u_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -217,8 +218,8 @@ func TestValidationError_ToMap(t *testing.T) {
 				return ve
 			},
 			expected: u.ToMapResult{
-				"username": {"errors": u.RuleErrors{"must be at least 3 characters"}},
-				"email":    {"errors": u.RuleErrors{"cannot be empty"}},
+				"username": {"errors": u.RuleErrors{{Message: "must be at least 3 characters"}}},
+				"email":    {"errors": u.RuleErrors{{Message: "cannot be empty"}}},
 			},
 		},
 		{
@@ -232,8 +233,8 @@ func TestValidationError_ToMap(t *testing.T) {
 			},
 			expected: u.ToMapResult{
 				"address": {
-					"street": map[string]any{"errors": u.RuleErrors{"cannot be empty"}},
-					"city":   map[string]any{"errors": u.RuleErrors{"cannot be empty"}},
+					"street": map[string]any{"errors": u.RuleErrors{{Message: "cannot be empty"}}},
+					"city":   map[string]any{"errors": u.RuleErrors{{Message: "cannot be empty"}}},
 				},
 			},
 		},
@@ -248,10 +249,10 @@ func TestValidationError_ToMap(t *testing.T) {
 			},
 			expected: u.ToMapResult{
 				"username": {
-					"errors": u.RuleErrors{"must be at least 3 characters"},
+					"errors": u.RuleErrors{{Message: "must be at least 3 characters"}},
 				},
 				"address": {
-					"street": map[string]any{"errors": u.RuleErrors{"cannot be empty"}},
+					"street": map[string]any{"errors": u.RuleErrors{{Message: "cannot be empty"}}},
 				},
 			},
 		},
@@ -267,7 +268,7 @@ func TestValidationError_ToMap(t *testing.T) {
 			expected: u.ToMapResult{
 				"user": {
 					"address": map[string]any{
-						"postcode": map[string]any{"errors": u.RuleErrors{"invalid format"}},
+						"postcode": map[string]any{"errors": u.RuleErrors{{Message: "invalid format"}}},
 					},
 				},
 			},
@@ -283,8 +284,8 @@ func TestValidationError_ToMap(t *testing.T) {
 			},
 			expected: u.ToMapResult{
 				"address": {
-					"errors": u.RuleErrors{"invalid address"},
-					"street": map[string]any{"errors": u.RuleErrors{"cannot be empty"}},
+					"errors": u.RuleErrors{{Message: "invalid address"}},
+					"street": map[string]any{"errors": u.RuleErrors{{Message: "cannot be empty"}}},
 				},
 			},
 		},
@@ -299,9 +300,9 @@ func TestValidationError_ToMap(t *testing.T) {
 			},
 			expected: u.ToMapResult{
 				"password": {"errors": u.RuleErrors{
-					"too short",
-					"needs special characters",
-					"needs numbers",
+					{Message: "too short"},
+					{Message: "needs special characters"},
+					{Message: "needs numbers"},
 				}},
 			},
 		},
@@ -461,6 +462,89 @@ func TestValidationError_Error(t *testing.T) {
 	}
 }
 
+func TestValidationError_MarshalJSON(t *testing.T) {
+	t.Run("outputs codes and params", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.AddError("username", u.Errorf("string.min_length", u.Params{"min": 3, "actual": 2},
+			"length is 2, but needs to be at least 3"))
+
+		// Act
+		bytes, err := json.Marshal(ve)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		expected := `{"username":{"errors":[{"code":"string.min_length","params":{"actual":2,"min":3},"message":"length is 2, but needs to be at least 3"}]}}`
+		if string(bytes) != expected {
+			t.Errorf("expected %s, got %s", expected, bytes)
+		}
+	})
+
+	t.Run("omits code and params for plain errors", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.AddError("email", errors.New("cannot be empty"))
+
+		// Act
+		bytes, err := json.Marshal(ve)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		expected := `{"email":{"errors":[{"message":"cannot be empty"}]}}`
+		if string(bytes) != expected {
+			t.Errorf("expected %s, got %s", expected, bytes)
+		}
+	})
+}
+
+func TestAsRuleError(t *testing.T) {
+	t.Run("keeps code and params of a RuleError", func(t *testing.T) {
+		// Arrange
+		err := u.Errorf("number.min", u.Params{"min": 18}, "too young")
+
+		// Act
+		re := u.AsRuleError(err)
+
+		// Assert
+		if re.Code != "number.min" {
+			t.Errorf("expected code %q, got %q", "number.min", re.Code)
+		}
+		if re.Params["min"] != 18 {
+			t.Errorf("expected param min to be 18, got %v", re.Params["min"])
+		}
+		assertErrorMessage(t, re.Message, "too young")
+	})
+
+	t.Run("keeps code of a wrapped RuleError and the full message", func(t *testing.T) {
+		// Arrange
+		err := fmt.Errorf("age: %w", u.Errorf("number.min", nil, "too young"))
+
+		// Act
+		re := u.AsRuleError(err)
+
+		// Assert
+		if re.Code != "number.min" {
+			t.Errorf("expected code %q, got %q", "number.min", re.Code)
+		}
+		assertErrorMessage(t, re.Message, "age: too young")
+	})
+
+	t.Run("uses the message of a plain error", func(t *testing.T) {
+		// Act
+		re := u.AsRuleError(errors.New("invalid"))
+
+		// Assert
+		if re.Code != "" {
+			t.Errorf("expected empty code, got %q", re.Code)
+		}
+		assertErrorMessage(t, re.Message, "invalid")
+	})
+}
+
 // Helpers
 
 func assertErroredFieldsLen(t *testing.T, ve *u.ValidationError, want int) {
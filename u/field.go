@@ -1,10 +1,130 @@
 package u
 
-// FieldState holds the value being validated and any validation errors.
-// It is passed to validation rules to provide access to the value.
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+)
+
+// FieldState holds the value being validated and where it belongs in the validated data.
+// It is passed to validation rules to provide access to the value, its path, the values it
+// is nested in and the data of the validation run.
 type FieldState[T any] struct {
-	Value  T
-	errors *ValidationError
+	Value T
+	run   *run
+
+	// path is the path of the field from the root of the validation run, see Path
+	path []FieldTag
+
+	// parent is the value the field belongs to, see Parent
+	parent any
+
+	// describe is set when a rule is called to retrieve its descriptor, see WithDescriptor
+	describe *RuleDescriptor
+
+	// transformed is set when a transform is called to retrieve the transformed value, see Transform
+	transformed *T
+}
+
+// Context returns the context of the validation run. Rules performing I/O should use it
+// to respect deadlines and cancellation. It returns context.Background() when the field
+// is not validated with a context.
+//
+// Example:
+//
+//	func UniqueUsername(fs u.FieldState[string]) error {
+//		taken, err := db.UsernameTaken(fs.Context(), fs.Value)
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (fs FieldState[T]) Context() context.Context {
+	return fs.run.context()
+}
+
+// ShortCircuit reports whether the rule should stop at its first failure, because the
+// validation run stops at the first error of a field or of the whole run. Rules validating
+// many values, such as the elements of a slice, should use it to skip unnecessary work.
+func (fs FieldState[T]) ShortCircuit() bool {
+	return fs.run.shortCircuit()
+}
+
+// Tag returns the tag of the field being validated, or the index or key of the element for the
+// states of the elements of a collection, see DeriveAt. It is empty outside of a schema.
+func (fs FieldState[T]) Tag() FieldTag {
+	if len(fs.path) == 0 {
+		return ""
+	}
+	return fs.path[len(fs.path)-1]
+}
+
+// Path returns the tags leading from the root of the validation run to the field, ending with
+// its Tag. It is the path its failures are reported under, see ValidationError.Path.
+//
+// Example:
+//
+//	u.JSONPointer.Format(fs.Path()) // "/items/2/quantity"
+func (fs FieldState[T]) Path() []FieldTag {
+	return slices.Clone(fs.path)
+}
+
+// Root returns the value bound to the schema at the root of the validation run, such as the value
+// validated by a Validator, see Bind. It returns nil if the root schema is not bound to a value.
+func (fs FieldState[T]) Root() any {
+	return fs.run.rootValue()
+}
+
+// Parent returns the value the field belongs to: the value bound to the nearest schema the field
+// is nested in, see Bind, or the collection for the states of the elements of a collection. It
+// returns nil if there is none.
+//
+// Example:
+//
+//	func BeforeDeadline(fs u.FieldState[time.Time]) error {
+//		order, ok := fs.Parent().(Order)
+//		if ok && fs.Value.After(order.Deadline) {
+//			return errors.New("must be before the deadline of the order")
+//		}
+//		return nil
+//	}
+func (fs FieldState[T]) Parent() any {
+	return fs.parent
+}
+
+// Derive returns a FieldState for value that belongs to the same validation run as parent.
+// Rules that validate a value derived from the value of a field, such as the value pointed
+// to by a pointer, should use it so that the inner rules share the run's context and the
+// path of the field.
+//
+// Example:
+//
+//	if fs.Value != nil {
+//		return rule(u.Derive(fs, *fs.Value))
+//	}
+func Derive[T, P any](parent FieldState[P], value T) FieldState[T] {
+	return FieldState[T]{Value: value, run: parent.run, path: parent.path, parent: parent.parent}
+}
+
+// DeriveAt returns a FieldState for value nested under tag in the value of parent, such as an
+// element of a slice, that belongs to the same validation run. Its path is the path of parent
+// followed by tag, and its parent value is the value of parent. Rules validating the elements
+// of a collection should use it so that the inner rules know which element they validate.
+//
+// Example:
+//
+//	for i, item := range fs.Value {
+//		if err := rule(u.DeriveAt(fs, strconv.Itoa(i), item)); err != nil {
+//			...
+//		}
+//	}
+func DeriveAt[T, P any](parent FieldState[P], tag FieldTag, value T) FieldState[T] {
+	return FieldState[T]{
+		Value:  value,
+		run:    parent.run,
+		path:   append(slices.Clip(parent.path), tag),
+		parent: parent.Value,
+	}
 }
 
 // FieldDef represents a field with its value and validation rules.
@@ -12,9 +132,19 @@ type FieldState[T any] struct {
 type FieldDef[T any] struct {
 	state FieldState[T]
 	rules []Rule[T]
+
+	// transforms and checks are the transforms and the other rules of the field, see Transform
+	transforms []Rule[T]
+	checks     []Rule[T]
+
+	// transformed is the transformed value of the field, once it has been validated
+	transformed atomic.Pointer[T]
 }
 
-var _ Validable = (*FieldDef[any])(nil)
+var (
+	_ Validable = (*FieldDef[any])(nil)
+	_ Describer = (*FieldDef[any])(nil)
+)
 
 // Field is the main function for creating a validatable field. It takes a value to validate,
 // and a set of rules that will match the type of the given value.
@@ -35,26 +165,67 @@ var _ Validable = (*FieldDef[any])(nil)
 //		return nil
 //	})
 func Field[T any](value T, rules ...Rule[T]) *FieldDef[T] {
+	transforms, checks := splitTransforms(rules)
 	return &FieldDef[T]{
 		state: FieldState[T]{
 			Value: value,
 		},
-		rules: rules,
+		rules:      rules,
+		transforms: transforms,
+		checks:     checks,
 	}
 }
 
 // Validate applies all rules to the field and adds any validation errors to the provided
-// ValidationError object under the specified tag.
+// ValidationError object under the specified tag. The transforms of the field are applied first,
+// and the transformed value is kept as the value of the field. If the context of the validation run is
+// cancelled, the remaining rules are skipped and the failure of the interrupted rule is discarded.
+// The remaining rules are also skipped when the run reaches its maximum number of errors, or
+// after the first failure that fails validation when the run bails.
 func (f *FieldDef[T]) Validate(ve *ValidationError, tag FieldTag) {
-	for _, rule := range f.rules {
-		ruleErr := rule(f.state)
-		if ruleErr != nil {
-			ve.AddError(tag, ruleErr)
+	state := f.state
+	state.run = ve.run
+	state.path = append(ve.Path(), tag)
+	state.parent = ve.boundValue()
+
+	if f.transforms != nil {
+		for _, transform := range f.transforms {
+			var value T
+			_ = transform(FieldState[T]{Value: state.Value, transformed: &value})
+			state.Value = value
 		}
+		value := state.Value
+		f.transformed.Store(&value)
+	}
+
+	for _, rule := range f.checks {
+		if ve.run.stopped() {
+			return
+		}
+
+		ruleErr := rule(state)
+		if ruleErr == nil || ve.run.cancelled() {
+			continue
+		}
+
+		ve.AddError(tag, ruleErr)
+		if ve.run != nil && ve.run.bail && blocking(ruleErr) {
+			return
+		}
+	}
+}
+
+// Value returns the value of the field. Once the field is validated, it is the value transformed
+// by the transforms of the field, see Transform.
+func (f *FieldDef[T]) Value() T {
+	if transformed := f.transformed.Load(); transformed != nil {
+		return *transformed
 	}
+	return f.state.Value
 }
 
-// Errors returns the validation errors associated with this field.
-func (f FieldDef[T]) Errors() *ValidationError {
-	return f.state.errors
+// Errors implements the Validable interface for FieldDef. Fields report their errors in the
+// ValidationError they are validated against, so it returns nil.
+func (f *FieldDef[T]) Errors() *ValidationError {
+	return nil
 }
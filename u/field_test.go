@@ -1,7 +1,12 @@
 package u_test
 
 import (
+	"context"
 	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/cachesdev/souuup/u"
@@ -51,7 +56,7 @@ func TestField_Validate(t *testing.T) {
 		tag           u.FieldTag
 		expectedError bool
 		errorField    string
-		errorMessages u.RuleErrors
+		errorMessages []string
 	}{
 		{
 			name:          "passes validation with no rules",
@@ -74,7 +79,7 @@ func TestField_Validate(t *testing.T) {
 			tag:           "username",
 			expectedError: true,
 			errorField:    "username",
-			errorMessages: u.RuleErrors{"validation failed"},
+			errorMessages: []string{"validation failed"},
 		},
 		{
 			name:          "reports multiple errors from multiple rules",
@@ -83,7 +88,7 @@ func TestField_Validate(t *testing.T) {
 			tag:           "password",
 			expectedError: true,
 			errorField:    "password",
-			errorMessages: u.RuleErrors{"error 1", "error 2"},
+			errorMessages: []string{"error 1", "error 2"},
 		},
 		{
 			name:          "reports only errors from failing rules",
@@ -92,7 +97,7 @@ func TestField_Validate(t *testing.T) {
 			tag:           "email",
 			expectedError: true,
 			errorField:    "email",
-			errorMessages: u.RuleErrors{"only error"},
+			errorMessages: []string{"only error"},
 		},
 		{
 			name:          "uses provided field tag for error",
@@ -101,7 +106,7 @@ func TestField_Validate(t *testing.T) {
 			tag:           "custom_field",
 			expectedError: true,
 			errorField:    "custom_field",
-			errorMessages: u.RuleErrors{"validation error"},
+			errorMessages: []string{"validation error"},
 		},
 	}
 
@@ -150,7 +155,7 @@ func TestField_Validate(t *testing.T) {
 					t.Errorf("missing expected error: %q", expected)
 					continue
 				}
-				if fieldErrors[i] != expected {
+				if fieldErrors[i].Message != expected {
 					t.Errorf("expected error message %q, got %q", expected, fieldErrors[i].Error())
 				}
 			}
@@ -205,3 +210,165 @@ func TestField_Errors(t *testing.T) {
 		}
 	})
 }
+
+func TestFieldState_Context(t *testing.T) {
+	t.Run("returns background context outside of a validation run", func(t *testing.T) {
+		// Arrange
+		fs := u.FieldState[int]{Value: 1}
+
+		// Act
+		ctx := fs.Context()
+
+		// Assert
+		if ctx != context.Background() {
+			t.Errorf("expected context.Background(), got %v", ctx)
+		}
+	})
+
+	t.Run("derived states share the context of their parent", func(t *testing.T) {
+		// Arrange
+		type ctxKey struct{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+		var got any
+		schema := u.Schema{
+			"items": u.Field([]int{1}, func(fs u.FieldState[[]int]) error {
+				got = u.Derive(fs, fs.Value[0]).Context().Value(ctxKey{})
+				return nil
+			}),
+		}
+
+		// Act
+		_ = u.NewSouuup(schema).ValidateContext(ctx)
+
+		// Assert
+		if got != "request" {
+			t.Errorf("expected derived state to carry the validation context, got value %v", got)
+		}
+	})
+}
+
+func TestFieldState_Path(t *testing.T) {
+	record := func(paths map[string][]u.FieldTag) u.Rule[int] {
+		return func(fs u.FieldState[int]) error {
+			paths[u.DottedPath.Format(fs.Path())] = fs.Path()
+			if fs.Tag() != fs.Path()[len(fs.Path())-1] {
+				t.Errorf("expected tag %q to end the path %v", fs.Tag(), fs.Path())
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		schema   func(map[string][]u.FieldTag) u.Object
+		expected []string
+	}{
+		{
+			name: "fields of the root schema",
+			schema: func(paths map[string][]u.FieldTag) u.Object {
+				return u.Schema{"age": u.Field(1, record(paths))}
+			},
+			expected: []string{"age"},
+		},
+		{
+			name: "fields of nested schemas",
+			schema: func(paths map[string][]u.FieldTag) u.Object {
+				return u.Schema{
+					"address": u.Ordered(u.Entry("number", u.Field(1, record(paths)))),
+					"items": u.Each([]int{1, 2}, func(qty int) u.Schema {
+						return u.Schema{"quantity": u.Field(qty, record(paths))}
+					}),
+				}
+			},
+			expected: []string{"address.number", "items.0.quantity", "items.1.quantity"},
+		},
+		{
+			name: "elements of a collection",
+			schema: func(paths map[string][]u.FieldTag) u.Object {
+				return u.Schema{"limits": u.Field([]int{1, 2}, func(fs u.FieldState[[]int]) error {
+					for i, limit := range fs.Value {
+						_ = record(paths)(u.DeriveAt(fs, strconv.Itoa(i), limit))
+					}
+					return nil
+				})}
+			},
+			expected: []string{"limits.0", "limits.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			paths := make(map[string][]u.FieldTag)
+			s := u.NewSouuup(tt.schema(paths))
+
+			// Act
+			_ = s.Validate()
+
+			// Assert
+			got := slices.Sorted(maps.Keys(paths))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected paths %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFieldState_RootAndParent(t *testing.T) {
+	type item struct{ Quantity int }
+	type order struct {
+		ID    string
+		Items []item
+	}
+
+	// Arrange
+	var roots, parents []any
+	record := func(fs u.FieldState[int]) error {
+		roots = append(roots, fs.Root())
+		parents = append(parents, fs.Parent())
+		return nil
+	}
+	value := order{ID: "A1", Items: []item{{Quantity: 2}}}
+	validator := u.Compile(func(o order) u.Schema {
+		return u.Schema{
+			"idLength": u.Field(len(o.ID), record),
+			"items": u.Each(o.Items, func(it item) u.Schema {
+				return u.Schema{"quantity": u.Field(it.Quantity, record)}
+			}),
+		}
+	})
+
+	// Act
+	err := validator.Validate(value)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, root := range roots {
+		if !reflect.DeepEqual(root, value) {
+			t.Errorf("expected root %v for field %d, got %v", value, i, root)
+		}
+	}
+	expectedParents := []any{value, value.Items[0]}
+	if !reflect.DeepEqual(parents, expectedParents) {
+		t.Errorf("expected parents %v, got %v", expectedParents, parents)
+	}
+}
+
+func TestFieldState_RootAndParent_Unbound(t *testing.T) {
+	// Arrange
+	var root, parent any = "unset", "unset"
+	s := u.NewSouuup(u.Schema{"age": u.Field(1, func(fs u.FieldState[int]) error {
+		root, parent = fs.Root(), fs.Parent()
+		return nil
+	})})
+
+	// Act
+	_ = s.Validate()
+
+	// Assert
+	if root != nil || parent != nil {
+		t.Errorf("expected no root and parent for an unbound schema, got %v and %v", root, parent)
+	}
+}
@@ -0,0 +1,135 @@
+package u
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PathStyle selects the syntax used to format field paths.
+type PathStyle int
+
+const (
+	// JSONPointer formats paths as RFC 6901 JSON Pointers, e.g. "/items/2/qty".
+	JSONPointer PathStyle = iota
+
+	// DottedPath formats paths as dot separated tags, e.g. "items.2.qty".
+	DottedPath
+
+	// BracketPath formats paths with numeric tags as indices, e.g. "items[2].qty".
+	BracketPath
+)
+
+// FlatError is a rule error qualified with the path of the field it belongs to.
+type FlatError struct {
+	// Path is the path of the field, formatted with the requested PathStyle.
+	Path string `json:"path"`
+
+	// Segments are the tags making up the path, from the root.
+	Segments []FieldTag `json:"-"`
+
+	Code     string   `json:"code,omitempty"`
+	Params   Params   `json:"params,omitempty"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// Flatten returns every error in the tree as a flat list of path-qualified errors, in the same
+// order as they are output by MarshalJSON. Paths are built from the tags leading from ve to each
+// error and are formatted with the given style.
+//
+// Example:
+//
+//	for _, fe := range ve.Flatten(u.JSONPointer) {
+//		fmt.Println(fe.Path, fe.Code, fe.Message)
+//	}
+//	// /username string.min_length length is 2, but needs to be at least 3
+//	// /address/city value.not_zero value is required but has zero value
+func (ve *ValidationError) Flatten(style PathStyle) []FlatError {
+	var result []FlatError
+	ve.flatten(style, nil, &result)
+	return result
+}
+
+// flatten appends the errors of ve and its nested errors to result. The path of ve is built from
+// the tags leading to it, so trees built by hand, without Parent links, are flattened too.
+func (ve *ValidationError) flatten(style PathStyle, path []FieldTag, result *[]FlatError) {
+	if !ve.HasErrors() {
+		return
+	}
+
+	for _, tag := range ve.tags() {
+		segments := append(slices.Clip(path), tag)
+		if errs := ve.Errors[tag]; len(errs) > 0 {
+			for _, err := range errs {
+				*result = append(*result, FlatError{
+					Path:     style.Format(segments),
+					Segments: segments,
+					Code:     err.Code,
+					Params:   err.Params,
+					Message:  err.Message,
+					Severity: err.Severity,
+				})
+			}
+		}
+
+		if nested, ok := ve.NestedErrors[tag]; ok {
+			nested.flatten(style, segments, result)
+		}
+	}
+}
+
+// Path returns the tags leading from the root of the tree to this ValidationError,
+// following the Parent links. It is empty for the root.
+func (ve *ValidationError) Path() []FieldTag {
+	var path []FieldTag
+	for node := ve; node.Parent != nil; node = node.Parent {
+		path = append(path, node.tag)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Format formats the segments of a path with the style.
+//
+// Example:
+//
+//	u.JSONPointer.Format([]string{"items", "2", "qty"}) // "/items/2/qty"
+//	u.DottedPath.Format([]string{"items", "2", "qty"})  // "items.2.qty"
+//	u.BracketPath.Format([]string{"items", "2", "qty"}) // "items[2].qty"
+func (style PathStyle) Format(segments []FieldTag) string {
+	var sb strings.Builder
+
+	for i, segment := range segments {
+		switch style {
+		case JSONPointer:
+			sb.WriteByte('/')
+			sb.WriteString(jsonPointerEscaper.Replace(segment))
+		case BracketPath:
+			if isIndex(segment) {
+				sb.WriteString("[" + segment + "]")
+				continue
+			}
+			fallthrough
+		case DottedPath:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(segment)
+		}
+	}
+
+	return sb.String()
+}
+
+// jsonPointerEscaper escapes the reference tokens of a JSON Pointer as defined by RFC 6901.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// isIndex reports whether a tag is a collection index.
+func isIndex(tag FieldTag) bool {
+	_, err := strconv.ParseUint(tag, 10, 64)
+	return err == nil
+}
@@ -0,0 +1,154 @@
+package u_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestValidationError_Flatten(t *testing.T) {
+	newErrors := func(t *testing.T) *u.ValidationError {
+		t.Helper()
+
+		schema := u.Ordered(
+			u.Entry("username", u.Field("ab", func(fs u.FieldState[string]) error {
+				return u.NewRuleError("string.min_length", u.Params{"min": 3, "actual": 2})
+			})),
+			u.Entry("address", u.Ordered(
+				u.Entry("city", &mockValidable{hasErrors: true, errorMessage: "cannot be empty"}),
+				u.Entry("a/b~c", &mockValidable{hasErrors: true, errorMessage: "escaped"}),
+			)),
+			u.Entry("items", u.Ordered(
+				u.Entry("2", u.Schema{
+					"qty": &mockValidable{hasErrors: true, errorMessage: "too many"},
+				}),
+			)),
+		)
+
+		var ve *u.ValidationError
+		if !errors.As(u.NewSouuup(schema).Validate(), &ve) {
+			t.Fatal("expected a ValidationError")
+		}
+		return ve
+	}
+
+	tests := []struct {
+		name     string
+		style    u.PathStyle
+		expected []string
+	}{
+		{
+			name:     "JSON Pointer paths",
+			style:    u.JSONPointer,
+			expected: []string{"/username", "/address/city", "/address/a~1b~0c", "/items/2/qty"},
+		},
+		{
+			name:     "dotted paths",
+			style:    u.DottedPath,
+			expected: []string{"username", "address.city", "address.a/b~c", "items.2.qty"},
+		},
+		{
+			name:     "bracket paths",
+			style:    u.BracketPath,
+			expected: []string{"username", "address.city", "address.a/b~c", "items[2].qty"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ve := newErrors(t)
+
+			// Act
+			flat := ve.Flatten(tc.style)
+
+			// Assert
+			paths := make([]string, len(flat))
+			for i, fe := range flat {
+				paths[i] = fe.Path
+			}
+			if !reflect.DeepEqual(paths, tc.expected) {
+				t.Errorf("expected paths %v, got %v", tc.expected, paths)
+			}
+		})
+	}
+
+	t.Run("keeps code, params and message", func(t *testing.T) {
+		// Arrange
+		ve := newErrors(t)
+
+		// Act
+		flat := ve.Flatten(u.JSONPointer)
+
+		// Assert
+		bytes, err := json.Marshal(flat[0])
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		expected := `{"path":"/username","code":"string.min_length","params":{"actual":2,"min":3},` +
+			`"message":"length is 2, but needs to be at least 3"}`
+		assertErrorMessage(t, string(bytes), expected)
+		if !reflect.DeepEqual(flat[3].Segments, []string{"items", "2", "qty"}) {
+			t.Errorf("expected segments [items 2 qty], got %v", flat[3].Segments)
+		}
+	})
+
+	t.Run("flattens trees built by hand", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.NestedErrors["address"] = &u.ValidationError{
+			Errors: u.FieldsErrorMap{"city": {{Message: "cannot be empty"}}},
+			NestedErrors: u.NestedErrorsMap{
+				"geo": {Errors: u.FieldsErrorMap{"lat": {{Message: "out of range"}}}},
+			},
+		}
+		ve.AddError("username", errors.New("too short"))
+
+		// Act
+		flat := ve.Flatten(u.JSONPointer)
+
+		// Assert
+		paths := make([]string, len(flat))
+		for i, fe := range flat {
+			paths[i] = fe.Path
+		}
+		expected := []string{"/address/city", "/address/geo/lat", "/username"}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("expected paths %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("returns nil for ValidationError with no errors", func(t *testing.T) {
+		// Act
+		flat := u.NewValidationError().Flatten(u.JSONPointer)
+
+		// Assert
+		if flat != nil {
+			t.Errorf("expected nil, got %v", flat)
+		}
+	})
+}
+
+func TestValidationError_Path(t *testing.T) {
+	t.Run("follows parent links to the root", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+
+		// Act
+		nested := ve.GetOrCreateNested("user").GetOrCreateNested("address")
+
+		// Assert
+		if nested.Parent == nil || nested.Parent.Parent != ve {
+			t.Error("expected nested ValidationErrors to be linked to their parents")
+		}
+		if !reflect.DeepEqual(nested.Path(), []string{"user", "address"}) {
+			t.Errorf("expected path [user address], got %v", nested.Path())
+		}
+		if len(ve.Path()) != 0 {
+			t.Errorf("expected empty path for the root, got %v", ve.Path())
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package u
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Locale identifies the language used to render error messages, e.g. "en", "es" or "pt-BR".
+type Locale = string
+
+// DefaultLocale is the locale used to render error messages when none is selected.
+const DefaultLocale Locale = "en"
+
+// Translator renders the message of a rule error in a given locale.
+// It is used by ValidationError.Translate to localise a whole error tree.
+type Translator interface {
+	// Translate returns the message for err in the given locale. The second return value
+	// is false if the translator has no message for the error's code in that locale.
+	Translate(locale Locale, err RuleError) (string, bool)
+}
+
+// PluralRule selects the plural form to use for a count, returning the index of the form
+// passed to the "plural" template function.
+type PluralRule func(n int) int
+
+// Catalog is a Translator backed by message templates keyed by locale and rule code.
+//
+// Messages are text/template templates executed against the rule params. Besides the
+// standard template functions, a "plural" function is available that picks one of its
+// forms based on a count and the locale's PluralRule:
+//
+//	"length is {{.actual}}, but needs to be at least {{.min}} {{plural .min \"character\" \"characters\"}}"
+//
+// When a locale has no message for a code, its base language is tried ("pt-BR" falls back to "pt").
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	mu        sync.RWMutex
+	templates map[Locale]map[string]*template.Template
+	plurals   map[Locale]PluralRule
+}
+
+var _ Translator = (*Catalog)(nil)
+
+//go:embed locales/*.json
+var bundledLocales embed.FS
+
+// DefaultCatalog is the catalog used to render the messages of rule errors. It contains
+// the bundled messages for every built-in rule in English, Spanish and Portuguese.
+// Custom rules can register their own messages in it.
+//
+// Example:
+//
+//	u.DefaultCatalog.MustRegister("en", "myapp.email", "{{printf \"%q\" .actual}} is not a valid email")
+//	u.DefaultCatalog.MustRegister("es", "myapp.email", "{{printf \"%q\" .actual}} no es un email válido")
+var DefaultCatalog = newBundledCatalog()
+
+// NewCatalog creates an empty catalog. Locales use a plural rule with one singular
+// form for a count of 1 unless another rule is set with SetPluralRule.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		templates: make(map[Locale]map[string]*template.Template),
+		plurals:   make(map[Locale]PluralRule),
+	}
+}
+
+// Register parses message and registers it as the template for code in the given locale,
+// replacing any existing one.
+func (c *Catalog) Register(locale Locale, code, message string) error {
+	locale = normaliseLocale(locale)
+
+	tmpl, err := template.New(code).Funcs(c.funcs(locale)).Parse(message)
+	if err != nil {
+		return fmt.Errorf("parsing message for %q in %q: %w", code, locale, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.templates[locale] == nil {
+		c.templates[locale] = make(map[string]*template.Template)
+	}
+	c.templates[locale][code] = tmpl
+	return nil
+}
+
+// MustRegister is like Register but panics if the message cannot be parsed.
+func (c *Catalog) MustRegister(locale Locale, code, message string) {
+	if err := c.Register(locale, code, message); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterMessages registers every message in messages, keyed by code, for the given locale.
+func (c *Catalog) RegisterMessages(locale Locale, messages map[string]string) error {
+	for code, message := range messages {
+		if err := c.Register(locale, code, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetPluralRule sets the plural rule used by the "plural" template function for a locale.
+func (c *Catalog) SetPluralRule(locale Locale, rule PluralRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plurals[normaliseLocale(locale)] = rule
+}
+
+// Translate implements the Translator interface. It renders the template registered for
+// the error's code in the given locale, or its base language, using the error params.
+func (c *Catalog) Translate(locale Locale, err RuleError) (string, bool) {
+	if err.Code == "" {
+		return "", false
+	}
+
+	c.mu.RLock()
+	var tmpl *template.Template
+	for _, candidate := range localeCandidates(locale) {
+		if tmpl = c.templates[candidate][err.Code]; tmpl != nil {
+			break
+		}
+	}
+	c.mu.RUnlock()
+
+	if tmpl == nil {
+		return "", false
+	}
+
+	params := err.Params
+	if params == nil {
+		params = Params{}
+	}
+
+	var sb strings.Builder
+	if execErr := tmpl.Execute(&sb, params); execErr != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+// funcs returns the template functions available to messages of a locale.
+func (c *Catalog) funcs(locale Locale) template.FuncMap {
+	return template.FuncMap{
+		"plural": func(count any, forms ...string) string {
+			if len(forms) == 0 {
+				return ""
+			}
+			idx := c.pluralRule(locale)(toInt(count))
+			return forms[max(0, min(idx, len(forms)-1))]
+		},
+	}
+}
+
+// pluralRule returns the plural rule for a locale, or its base language.
+func (c *Catalog) pluralRule(locale Locale) PluralRule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, candidate := range localeCandidates(locale) {
+		if rule, ok := c.plurals[candidate]; ok {
+			return rule
+		}
+	}
+	return oneOther
+}
+
+// oneOther is the plural rule of languages such as English and Spanish, with a singular form for 1.
+func oneOther(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// zeroOneOther is the plural rule of languages such as Portuguese and French, with a singular form for 0 and 1.
+func zeroOneOther(n int) int {
+	if n == 0 || n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// newBundledCatalog creates a catalog containing the bundled messages.
+func newBundledCatalog() *Catalog {
+	c := NewCatalog()
+	c.SetPluralRule("pt", zeroOneOther)
+
+	files, err := bundledLocales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, file := range files {
+		data, err := bundledLocales.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Errorf("parsing bundled locale %s: %w", file.Name(), err))
+		}
+
+		locale := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		if err := c.RegisterMessages(locale, messages); err != nil {
+			panic(err)
+		}
+	}
+
+	return c
+}
+
+// Translate replaces the message of every rule error in the tree with its translation in
+// the given locale. Errors the translator has no message for keep their current message.
+//
+// Example:
+//
+//	if ve, ok := err.(*u.ValidationError); ok {
+//		ve.Translate(u.DefaultCatalog, "es")
+//	}
+func (ve *ValidationError) Translate(t Translator, locale Locale) {
+	for tag, errs := range ve.Errors {
+		for i, err := range errs {
+			ve.Errors[tag][i] = translate(t, locale, err)
+		}
+	}
+	for tag, errs := range ve.notices {
+		for i, err := range errs {
+			ve.notices[tag][i] = translate(t, locale, err)
+		}
+	}
+
+	for _, nested := range ve.NestedErrors {
+		nested.Translate(t, locale)
+	}
+}
+
+// translate translates a rule error, including any rule errors in its params, so that
+// messages of composite rules are rendered from translated children.
+func translate(t Translator, locale Locale, err RuleError) RuleError {
+	if len(err.Params) > 0 {
+		params := make(Params, len(err.Params))
+		for k, v := range err.Params {
+			switch v := v.(type) {
+			case RuleError:
+				params[k] = translate(t, locale, v)
+			case []RuleError:
+				translated := make([]RuleError, len(v))
+				for i, child := range v {
+					translated[i] = translate(t, locale, child)
+				}
+				params[k] = translated
+			case []ElementError:
+				translated := make([]ElementError, len(v))
+				for i, child := range v {
+					translated[i] = ElementError{Index: child.Index, Error: translate(t, locale, child.Error)}
+				}
+				params[k] = translated
+			case []BranchError:
+				translated := make([]BranchError, len(v))
+				for i, child := range v {
+					child.Error = translate(t, locale, child.Error)
+					translated[i] = child
+				}
+				params[k] = translated
+			default:
+				params[k] = v
+			}
+		}
+		err.Params = params
+	}
+
+	if msg, ok := t.Translate(locale, err); ok {
+		err.Message = msg
+	}
+	return err
+}
+
+// normaliseLocale lower-cases a locale and uses "-" as the region separator.
+func normaliseLocale(locale Locale) Locale {
+	return strings.ReplaceAll(strings.ToLower(locale), "_", "-")
+}
+
+// localeCandidates returns the locales to try for a locale, from most to least specific.
+func localeCandidates(locale Locale) []Locale {
+	locale = normaliseLocale(locale)
+	if base, _, found := strings.Cut(locale, "-"); found {
+		return []Locale{locale, base}
+	}
+	return []Locale{locale}
+}
+
+// toInt converts a numeric template argument to an int.
+func toInt(v any) int {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return int(rv.Int())
+	case rv.CanUint():
+		return int(rv.Uint())
+	case rv.CanFloat():
+		return int(rv.Float())
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,255 @@
+package u_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestCatalog_Translate(t *testing.T) {
+	newCatalog := func(t *testing.T) *u.Catalog {
+		t.Helper()
+		c := u.NewCatalog()
+		err := c.RegisterMessages("en", map[string]string{
+			"string.min_length": "length is {{.actual}}, but needs to be at least {{.min}}",
+			"slice.every":       "{{.failed}} {{plural .failed \"element\" \"elements\"}} failed validation",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error registering messages: %v", err)
+		}
+		c.MustRegister("es", "string.min_length", "la longitud es {{.actual}}, pero debe ser al menos {{.min}}")
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		locale   u.Locale
+		err      u.RuleError
+		expected string
+		found    bool
+	}{
+		{
+			name:     "renders template with params",
+			locale:   "en",
+			err:      u.RuleError{Code: "string.min_length", Params: u.Params{"min": 3, "actual": 2}},
+			expected: "length is 2, but needs to be at least 3",
+			found:    true,
+		},
+		{
+			name:     "renders template for another locale",
+			locale:   "es",
+			err:      u.RuleError{Code: "string.min_length", Params: u.Params{"min": 3, "actual": 2}},
+			expected: "la longitud es 2, pero debe ser al menos 3",
+			found:    true,
+		},
+		{
+			name:     "falls back to the base language",
+			locale:   "es-MX",
+			err:      u.RuleError{Code: "string.min_length", Params: u.Params{"min": 3, "actual": 2}},
+			expected: "la longitud es 2, pero debe ser al menos 3",
+			found:    true,
+		},
+		{
+			name:     "uses singular plural form",
+			locale:   "en",
+			err:      u.RuleError{Code: "slice.every", Params: u.Params{"failed": 1}},
+			expected: "1 element failed validation",
+			found:    true,
+		},
+		{
+			name:     "uses plural form",
+			locale:   "en",
+			err:      u.RuleError{Code: "slice.every", Params: u.Params{"failed": 3}},
+			expected: "3 elements failed validation",
+			found:    true,
+		},
+		{
+			name:   "reports missing code",
+			locale: "en",
+			err:    u.RuleError{Code: "unknown"},
+			found:  false,
+		},
+		{
+			name:   "reports missing locale",
+			locale: "fr",
+			err:    u.RuleError{Code: "string.min_length"},
+			found:  false,
+		},
+		{
+			name:   "reports errors without code",
+			locale: "en",
+			err:    u.RuleError{Message: "custom"},
+			found:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			c := newCatalog(t)
+
+			// Act
+			msg, found := c.Translate(tc.locale, tc.err)
+
+			// Assert
+			if found != tc.found {
+				t.Fatalf("expected found to be %v, got %v", tc.found, found)
+			}
+			assertErrorMessage(t, msg, tc.expected)
+		})
+	}
+}
+
+func TestCatalog_Register(t *testing.T) {
+	t.Run("returns an error for invalid templates", func(t *testing.T) {
+		// Arrange
+		c := u.NewCatalog()
+
+		// Act
+		err := c.Register("en", "broken", "{{.min")
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for an invalid template")
+		}
+	})
+
+	t.Run("uses locale plural rule", func(t *testing.T) {
+		// Arrange
+		c := u.NewCatalog()
+		c.SetPluralRule("pt", func(n int) int {
+			if n <= 1 {
+				return 0
+			}
+			return 1
+		})
+		c.MustRegister("pt", "count", "{{.n}} {{plural .n \"elemento\" \"elementos\"}}")
+
+		// Act
+		msg, _ := c.Translate("pt-BR", u.RuleError{Code: "count", Params: u.Params{"n": 0}})
+
+		// Assert
+		assertErrorMessage(t, msg, "0 elemento")
+	})
+}
+
+func TestNewRuleError(t *testing.T) {
+	t.Run("renders message from the default catalog", func(t *testing.T) {
+		// Act
+		re := u.NewRuleError("string.min_length", u.Params{"min": 3, "actual": 2})
+
+		// Assert
+		assertErrorMessage(t, re.Message, "length is 2, but needs to be at least 3")
+	})
+
+	t.Run("renders registered custom messages", func(t *testing.T) {
+		// Arrange
+		u.DefaultCatalog.MustRegister("en", "test.custom", "custom {{.value}}")
+
+		// Act
+		re := u.NewRuleError("test.custom", u.Params{"value": 42})
+
+		// Assert
+		assertErrorMessage(t, re.Message, "custom 42")
+	})
+
+	t.Run("uses code as message for unknown codes", func(t *testing.T) {
+		// Act
+		re := u.NewRuleError("test.unknown", nil)
+
+		// Assert
+		assertErrorMessage(t, re.Message, "test.unknown")
+	})
+}
+
+func TestValidationError_Translate(t *testing.T) {
+	t.Run("translates errors at every level", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.AddError("username", u.NewRuleError("string.min_length", u.Params{"min": 3, "actual": 2}))
+		ve.GetOrCreateNested("address").AddError("city", u.NewRuleError("value.not_zero", nil))
+
+		// Act
+		ve.Translate(u.DefaultCatalog, "es")
+
+		// Assert
+		assertErrorMessage(t, ve.Errors["username"][0].Message,
+			"la longitud es 2, pero debe tener al menos 3 caracteres")
+		assertErrorMessage(t, ve.NestedErrors["address"].Errors["city"][0].Message,
+			"el valor es obligatorio, pero está vacío")
+	})
+
+	t.Run("translates element errors of composite rules", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.AddError("tags", u.NewRuleError("slice.every", u.Params{
+			"failed": 1,
+			"errors": []u.ElementError{
+				{Index: 2, Error: u.NewRuleError("string.min_length", u.Params{"min": 1, "actual": 0})},
+			},
+		}))
+
+		// Act
+		ve.Translate(u.DefaultCatalog, "pt")
+
+		// Assert
+		assertErrorMessage(t, ve.Errors["tags"][0].Message,
+			"1 elemento falhou na validação\n  [2]: o comprimento é 0, mas precisa ter pelo menos 1 caractere")
+	})
+
+	t.Run("keeps messages without translation", func(t *testing.T) {
+		// Arrange
+		ve := u.NewValidationError()
+		ve.AddError("email", errors.New("must be a valid email"))
+
+		// Act
+		ve.Translate(u.DefaultCatalog, "es")
+
+		// Assert
+		assertErrorMessage(t, ve.Errors["email"][0].Message, "must be a valid email")
+	})
+}
+
+func TestSouuup_Validate_WithLocale(t *testing.T) {
+	t.Run("renders messages in the selected locale", func(t *testing.T) {
+		// Arrange
+		s := u.NewSouuup(u.Schema{
+			"age": u.Field(15, func(fs u.FieldState[int]) error {
+				return u.NewRuleError("number.min", u.Params{"min": 18, "actual": fs.Value})
+			}),
+		})
+
+		// Act
+		err := s.Validate(u.WithLocale("pt-BR"))
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		if !strings.Contains(err.Error(), "o valor é 15, mas precisa ser no mínimo 18") {
+			t.Errorf("expected translated error message, got %q", err.Error())
+		}
+	})
+
+	t.Run("uses the provided translator", func(t *testing.T) {
+		// Arrange
+		c := u.NewCatalog()
+		c.MustRegister("en", "number.min", "too small")
+		s := u.NewSouuup(u.Schema{
+			"age": u.Field(15, func(fs u.FieldState[int]) error {
+				return u.NewRuleError("number.min", u.Params{"min": 18, "actual": fs.Value})
+			}),
+		})
+
+		// Act
+		err := s.Validate(u.WithLocale("en"), u.WithTranslator(c))
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"age":{"errors":["too small"]}}`)
+	})
+}
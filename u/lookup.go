@@ -0,0 +1,203 @@
+package u
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrLookupFailed is returned, wrapping the loader error, when the loader of a Batch fails
+// during a validation run.
+var ErrLookupFailed = errors.New("lookup failed")
+
+// CodeLookup is the descriptor name of the rules returned by Lookup.
+const CodeLookup = "rule.lookup"
+
+// Loader loads the values of many keys at once, such as with a single database query. Keys
+// without a value are left out of the returned map.
+type Loader[K comparable, V any] = func(ctx context.Context, keys []K) (map[K]V, error)
+
+// LookupCheck checks the result of looking up the value of a field: the value loaded for it,
+// and whether the loader found one. It returns an error if validation fails.
+type LookupCheck[K comparable, V any] = func(fs FieldState[K], value V, found bool) error
+
+// Batch groups the lookups of a validation run so that they are loaded with a single call to
+// its loader, following the DataLoader pattern. It is safe for concurrent use, and is usually
+// declared once per kind of lookup.
+type Batch[K comparable, V any] struct {
+	name string
+	load Loader[K, V]
+}
+
+// NewBatch returns a batch loading the lookups of a validation run with load. The name
+// describes the lookups in the descriptors of their rules and in loader errors.
+//
+// Example:
+//
+//	var products = u.NewBatch("products", func(ctx context.Context, skus []string) (map[string]Product, error) {
+//		return db.ProductsBySKU(ctx, skus) // SELECT ... WHERE sku = ANY($1)
+//	})
+func NewBatch[K comparable, V any](name string, load Loader[K, V]) *Batch[K, V] {
+	return &Batch[K, V]{name: name, load: load}
+}
+
+// String returns the name of the batch.
+func (b *Batch[K, V]) String() string {
+	return b.name
+}
+
+// Lookup returns a rule that looks up the value of a field with a batch, and validates the
+// result with check. Lookups are collected while the schema is validated, and are loaded
+// once every other rule has run, with one call to the loader of each batch for every unique
+// value. Failures are then reported at the path of the field, or of the element for the
+// elements of a collection, such as those validated by r.Every.
+//
+// Since the rule only registers the lookup, it always passes when applied, so it should not
+// be combined with rules that depend on its result, such as r.Not, r.Or, r.Some or u.Warn.
+// Outside of a validation run, the value is loaded on its own when the rule is applied.
+//
+// Example:
+//
+//	// One query for all the SKUs of an order
+//	skusField := u.Field(order.SKUs, r.Every(u.Lookup(products, func(fs u.FieldState[string], _ Product, found bool) error {
+//		if !found {
+//			return fmt.Errorf("product %s does not exist", fs.Value)
+//		}
+//		return nil
+//	})))
+//	// {"skus":{"3":{"errors":["product X-1 does not exist"]}}}
+func Lookup[K comparable, V any](b *Batch[K, V], check LookupCheck[K, V]) Rule[K] {
+	return WithDescriptor(NewRuleDescriptor(CodeLookup, Params{"batch": b.name}),
+		func(fs FieldState[K]) error {
+			if fs.run == nil {
+				values, err := b.load(fs.Context(), []K{fs.Value})
+				if err != nil {
+					return fmt.Errorf("%w: %s: %w", ErrLookupFailed, b.name, err)
+				}
+				value, found := values[fs.Value]
+				return check(fs, value, found)
+			}
+
+			registerLookup(fs.run, b, lookup[K, V]{state: fs, check: check})
+			return nil
+		})
+}
+
+// lookup is a lookup registered by a rule, waiting for its batch to be loaded.
+type lookup[K comparable, V any] struct {
+	state FieldState[K]
+	check LookupCheck[K, V]
+}
+
+// pendingBatch is the set of lookups of a batch registered during a validation run.
+type pendingBatch interface {
+	// name returns the name of the batch.
+	name() string
+
+	// resolve loads the lookups and calls report with the path and error of every failure.
+	resolve(ctx context.Context, report func(path []FieldTag, err error)) error
+}
+
+// pendingLookups is the set of lookups of a Batch registered during a validation run.
+type pendingLookups[K comparable, V any] struct {
+	batch   *Batch[K, V]
+	lookups []lookup[K, V]
+}
+
+// registerLookup registers l with the lookups of batch b in the validation run r.
+func registerLookup[K comparable, V any](r *run, b *Batch[K, V], l lookup[K, V]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pending := range r.batches {
+		if p, ok := pending.(*pendingLookups[K, V]); ok && p.batch == b {
+			p.lookups = append(p.lookups, l)
+			return
+		}
+	}
+	r.batches = append(r.batches, &pendingLookups[K, V]{batch: b, lookups: []lookup[K, V]{l}})
+}
+
+// name implements the pendingBatch interface for pendingLookups.
+func (p *pendingLookups[K, V]) name() string {
+	return p.batch.name
+}
+
+// resolve implements the pendingBatch interface for pendingLookups. Lookups are sorted by path,
+// so the loader receives the same keys in the same order whether or not the run is concurrent.
+func (p *pendingLookups[K, V]) resolve(ctx context.Context, report func(path []FieldTag, err error)) error {
+	slices.SortStableFunc(p.lookups, func(a, b lookup[K, V]) int {
+		return slices.Compare(a.state.path, b.state.path)
+	})
+
+	keys := make([]K, 0, len(p.lookups))
+	seen := make(map[K]struct{}, len(p.lookups))
+	for _, l := range p.lookups {
+		if _, ok := seen[l.state.Value]; !ok {
+			seen[l.state.Value] = struct{}{}
+			keys = append(keys, l.state.Value)
+		}
+	}
+
+	values, err := p.batch.load(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrLookupFailed, p.batch.name, err)
+	}
+
+	for _, l := range p.lookups {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		value, found := values[l.state.Value]
+		if err := l.check(l.state, value, found); err != nil {
+			report(l.state.path, err)
+		}
+	}
+	return nil
+}
+
+// resolveLookups loads the lookups registered during the run, reporting their failures in ve,
+// the root of the tree. Batches are loaded in parallel when the run is concurrent. It returns
+// the first loader error, by batch name.
+func (r *run) resolveLookups(ve *ValidationError) error {
+	if r == nil || len(r.batches) == 0 {
+		return nil
+	}
+
+	batches := slices.Clone(r.batches)
+	slices.SortStableFunc(batches, func(a, b pendingBatch) int {
+		return strings.Compare(a.name(), b.name())
+	})
+
+	errs := make([]error, len(batches))
+	r.forEach(len(batches), func(i int) {
+		if r.cancelled() {
+			return
+		}
+		errs[i] = batches[i].resolve(r.ctx, ve.addAt)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAt adds err under path, relative to ve. Errors without a path are added under RootTag.
+func (ve *ValidationError) addAt(path []FieldTag, err error) {
+	if len(path) == 0 {
+		ve.AddError(RootTag, err)
+		return
+	}
+
+	node := ve
+	for _, tag := range path[:len(path)-1] {
+		node = node.GetOrCreateNested(tag)
+	}
+	node.AddError(path[len(path)-1], err)
+}
@@ -0,0 +1,204 @@
+package u_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+// memLoader is an in-memory loader recording the keys of every call.
+type memLoader struct {
+	mu     sync.Mutex
+	values map[string]int
+	calls  [][]string
+	err    error
+}
+
+func (l *memLoader) load(_ context.Context, keys []string) (map[string]int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls = append(l.calls, keys)
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	values := make(map[string]int, len(keys))
+	for _, key := range keys {
+		if value, ok := l.values[key]; ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+func inStock(fs u.FieldState[string], stock int, found bool) error {
+	if !found {
+		return fmt.Errorf("%s does not exist", fs.Value)
+	}
+	if stock == 0 {
+		return fmt.Errorf("%s is out of stock", fs.Value)
+	}
+	return nil
+}
+
+func orderSchema(stock *u.Batch[string, int], gift string, skus []string) u.Schema {
+	return u.Schema{
+		"gift": u.Field(gift, u.Lookup(stock, inStock)),
+		"items": u.Each(skus, func(sku string) u.Schema {
+			return u.Schema{"sku": u.Field(sku, u.Lookup(stock, inStock))}
+		}),
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		gift     string
+		skus     []string
+		opts     []u.Option
+		expected string
+		keys     []string
+	}{
+		{
+			name: "found values",
+			gift: "A-1",
+			skus: []string{"B-1", "C-1"},
+			keys: []string{"A-1", "B-1", "C-1"},
+		},
+		{
+			name:     "failures at the path of each lookup",
+			gift:     "X-1",
+			skus:     []string{"B-1", "Y-1", "Z-1"},
+			expected: `{"gift":{"errors":["X-1 does not exist"]},"items":{"1":{"sku":{"errors":["Y-1 does not exist"]}},"2":{"sku":{"errors":["Z-1 is out of stock"]}}}}`,
+			keys:     []string{"X-1", "B-1", "Y-1", "Z-1"},
+		},
+		{
+			name:     "repeated values loaded once",
+			gift:     "Y-1",
+			skus:     []string{"Y-1", "A-1", "Y-1"},
+			expected: `{"gift":{"errors":["Y-1 does not exist"]},"items":{"0":{"sku":{"errors":["Y-1 does not exist"]}},"2":{"sku":{"errors":["Y-1 does not exist"]}}}}`,
+			keys:     []string{"Y-1", "A-1"},
+		},
+		{
+			name:     "concurrent run",
+			gift:     "A-1",
+			skus:     []string{"Z-1", "B-1", "Y-1", "C-1"},
+			opts:     []u.Option{u.WithConcurrency(4)},
+			expected: `{"items":{"0":{"sku":{"errors":["Z-1 is out of stock"]}},"2":{"sku":{"errors":["Y-1 does not exist"]}}}}`,
+			keys:     []string{"A-1", "Z-1", "B-1", "Y-1", "C-1"},
+		},
+		{
+			name:     "maximum number of errors",
+			gift:     "X-1",
+			skus:     []string{"Y-1", "Z-1"},
+			opts:     []u.Option{u.WithMaxErrors(2)},
+			expected: `{"gift":{"errors":["X-1 does not exist"]},"items":{"0":{"sku":{"errors":["Y-1 does not exist"]}}}}`,
+			keys:     []string{"X-1", "Y-1", "Z-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			loader := &memLoader{values: map[string]int{"A-1": 3, "B-1": 1, "C-1": 7, "Z-1": 0}}
+			stock := u.NewBatch("stock", loader.load)
+			s := u.NewSouuup(orderSchema(stock, tt.gift, tt.skus))
+
+			// Act
+			err := s.Validate(tt.opts...)
+
+			// Assert
+			if got := messagesOf(asValidationError(t, err)); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+			if len(loader.calls) != 1 || !slices.Equal(loader.calls[0], tt.keys) {
+				t.Errorf("expected a single call with %v, got %v", tt.keys, loader.calls)
+			}
+		})
+	}
+}
+
+func TestLookup_Batches(t *testing.T) {
+	// Arrange
+	loader := &memLoader{values: map[string]int{"A-1": 1}}
+	stock := u.NewBatch("stock", loader.load)
+	catalog := u.NewBatch("catalog", loader.load)
+	s := u.NewSouuup(u.Schema{
+		"sku":     u.Field("A-1", u.Lookup(stock, inStock)),
+		"related": u.Field("B-1", u.Lookup(catalog, inStock)),
+	})
+
+	// Act
+	err := s.Validate()
+
+	// Assert
+	expected := `{"related":{"errors":["B-1 does not exist"]}}`
+	if got := messagesOf(asValidationError(t, err)); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if want := [][]string{{"B-1"}, {"A-1"}}; !slices.EqualFunc(loader.calls, want, slices.Equal) {
+		t.Errorf("expected a call per batch, by name, got %v", loader.calls)
+	}
+}
+
+func TestLookup_LoaderError(t *testing.T) {
+	// Arrange
+	loader := &memLoader{err: errors.New("connection refused")}
+	s := u.NewSouuup(orderSchema(u.NewBatch("stock", loader.load), "A-1", []string{"B-1"}))
+
+	// Act
+	err := s.Validate()
+
+	// Assert
+	if !errors.Is(err, u.ErrLookupFailed) || !errors.Is(err, loader.err) {
+		t.Fatalf("expected an error wrapping the loader error, got %v", err)
+	}
+	if expected := "lookup failed: stock: connection refused"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestLookup_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	loader := &memLoader{}
+	cancelling := func(fs u.FieldState[string]) error {
+		cancel()
+		return nil
+	}
+	s := u.NewSouuup(u.Ordered(
+		u.Entry("sku", u.Field("A-1", u.Lookup(u.NewBatch("stock", loader.load), inStock))),
+		u.Entry("cancel", u.Field("", cancelling)),
+	))
+
+	// Act
+	err := s.ValidateContext(ctx)
+
+	// Assert
+	if !errors.Is(err, u.ErrValidationCancelled) {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+	if len(loader.calls) != 0 {
+		t.Errorf("expected no lookups to be loaded, got %v", loader.calls)
+	}
+}
+
+func TestLookup_OutsideOfRun(t *testing.T) {
+	// Arrange
+	loader := &memLoader{values: map[string]int{"A-1": 0}}
+	rule := u.Lookup(u.NewBatch("stock", loader.load), inStock)
+
+	// Act
+	err := rule(u.FieldState[string]{Value: "A-1"})
+
+	// Assert
+	if err == nil || err.Error() != "A-1 is out of stock" {
+		t.Errorf("expected the lookup to be checked, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package u
+
+// CodePtr is the descriptor name of the rules returned by Ptr.
+const CodePtr = "rule.ptr"
+
+// Ptr returns a rule for pointers that validates the value pointed to with rules, and
+// passes for nil pointers. Every failure of the rules is reported on the field, as with When.
+// Use it with r.Required to also require the pointer to be set.
+//
+// Example:
+//
+//	// The nickname is optional, but must be at least 3 characters long when given
+//	nicknameField := u.Field(user.Nickname, u.Ptr(r.MinS(3), r.MaxS(20)))
+//
+//	// The ship date is required, and must be in the future
+//	shipDateField := u.Field(order.ShipDate, r.Required[time.Time], u.Ptr(FutureDate))
+func Ptr[T any](rules ...Rule[T]) Rule[*T] {
+	return WithDescriptor(NewRuleDescriptor(CodePtr, Params{"rules": describeRules(rules)}),
+		func(fs FieldState[*T]) error {
+			if fs.Value == nil {
+				return nil
+			}
+			return applyRules(Derive(fs, *fs.Value), rules)
+		})
+}
+
+// Optional creates a field for an optional value, validating the value pointed to with rules
+// only if value is not nil. It is a shorthand for Field(value, Ptr(rules...)).
+//
+// Example:
+//
+//	schema := u.Schema{
+//		"nickname": u.Optional(user.Nickname, r.MinS(3)),
+//		"shipDate": u.Optional(order.ShipDate, FutureDate),
+//	}
+func Optional[T any](value *T, rules ...Rule[T]) *FieldDef[*T] {
+	return Field(value, Ptr(rules...))
+}
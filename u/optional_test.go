@@ -0,0 +1,65 @@
+package u_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestOptional(t *testing.T) {
+	short, long := "ab", "abcd"
+	minLength := func(fs u.FieldState[string]) error {
+		if len(fs.Value) < 3 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		field    u.Validable
+		expected string
+	}{
+		{name: "Optional: nil pointer", field: u.Optional(nil, minLength), expected: ""},
+		{name: "Optional: valid value", field: u.Optional(&long, minLength), expected: ""},
+		{name: "Optional: invalid value", field: u.Optional(&short, minLength), expected: `{"field":{"errors":["too short"]}}`},
+		{name: "Ptr: nil pointer", field: u.Field[*string](nil, u.Ptr(minLength)), expected: ""},
+		{name: "Ptr: invalid value", field: u.Field(&short, u.Ptr(minLength)), expected: `{"field":{"errors":["too short"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(u.Schema{"field": tt.field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPtr_Descriptor(t *testing.T) {
+	// Arrange
+	rule := u.Ptr(u.WithDescriptor(u.NewRuleDescriptor("string.min_length", u.Params{"min": 3}), alwaysValid))
+
+	// Act
+	desc := u.DescriptorOf(rule)
+
+	// Assert
+	if desc.Name != u.CodePtr {
+		t.Errorf("expected name %q, got %q", u.CodePtr, desc.Name)
+	}
+	if expected := "when present: must be at least 3 characters long"; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
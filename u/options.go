@@ -0,0 +1,89 @@
+package u
+
+// Option configures a single validation run.
+type Option func(*options)
+
+// options holds the configuration of a validation run.
+type options struct {
+	locale      Locale
+	translator  Translator
+	concurrency int
+	maxErrors   int
+	bail        bool
+	data        map[any]any
+}
+
+// newOptions applies opts over the default options.
+func newOptions(opts []Option) options {
+	o := options{translator: DefaultCatalog}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithLocale renders the messages of the returned errors in the given locale.
+// Errors without a message for the locale keep their default message.
+//
+// Example:
+//
+//	err := s.Validate(u.WithLocale("es"))
+func WithLocale(locale Locale) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// WithTranslator sets the translator used to render messages when a locale is selected
+// with WithLocale. It defaults to the DefaultCatalog.
+func WithTranslator(t Translator) Option {
+	return func(o *options) {
+		o.translator = t
+	}
+}
+
+// WithConcurrency validates the fields and nested schemas of a schema, and the elements of
+// slices validated by r.Every, r.Some and r.None, in parallel using up to n extra goroutines.
+// The resulting errors are reported in the same order as in a sequential run. When the number
+// of errors is limited, see WithMaxErrors, every field is still validated and the first errors
+// in reporting order are kept. These are the errors a sequential run reports, unless schema
+// rules or lookups fail: they run last, but are not reported last. Rules must be safe for
+// concurrent use when this option is set. Values of n lower than 2 validate sequentially.
+//
+// Example:
+//
+//	err := s.Validate(u.WithConcurrency(runtime.GOMAXPROCS(0)))
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithFailFast stops the validation at the first error, so at most one error is reported.
+// It is useful as a cheap check when only a yes/no answer is needed.
+//
+// Example:
+//
+//	if err := s.Validate(u.WithFailFast()); err != nil {
+//		return errInvalidRequest
+//	}
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithMaxErrors stops the validation once n errors have been reported, across all fields
+// and nested schemas. Values of n lower than 1 report every error. Concurrent runs apply the
+// limit once every field is validated, see WithConcurrency.
+func WithMaxErrors(n int) Option {
+	return func(o *options) {
+		o.maxErrors = n
+	}
+}
+
+// WithBail stops running the remaining rules of a field after its first failure, so each
+// field reports at most one error. Other fields are still validated.
+func WithBail() Option {
+	return func(o *options) {
+		o.bail = true
+	}
+}
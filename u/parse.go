@@ -0,0 +1,407 @@
+package u
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cachesdev/souuup/internal/structtag"
+)
+
+// CodeType is the error code reported when a value does not have the expected type.
+const CodeType = "value.type"
+
+// ErrInvalidJSON is returned by ParseJSON, wrapping the decoding error, when its data is not
+// valid JSON.
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// JSON type names reported in type errors.
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeNumber  = "number"
+	typeInteger = "integer"
+	typeString  = "string"
+	typeObject  = "object"
+	typeArray   = "array"
+)
+
+// Parse decodes untyped data, such as a decoded JSON payload, into a value of type T and
+// validates it with the schema returned by schema for it. It returns the value if it is valid.
+// Otherwise, it returns a *ValidationError with the failures of the schema, where values of
+// the wrong type are reported at their path with the CodeType error, e.g. "expected number,
+// got string", instead of the failures of their field.
+//
+// Properties are decoded into the fields of structs by their json names, like encoding/json.
+// Strings are coerced to numbers and booleans when they can be parsed as such, and numbers are
+// coerced to integers when they have no fractional part. Non-finite numbers, such as "NaN" and
+// "Inf", are rejected. Null values are decoded as zero values.
+//
+// Example:
+//
+//	func (p Payment) Schema() u.Schema {
+//		return u.Schema{"amount": u.Field(p.Amount, r.Gt(0.0))}
+//	}
+//
+//	payment, err := u.Parse(webhook, Payment.Schema)
+//	// {"amount":{"errors":["expected number, got boolean"]}}
+func Parse[T any, S Object](data map[string]any, schema func(T) S, opts ...Option) (T, error) {
+	return parse(data, schema, opts)
+}
+
+// ParseJSON is like Parse, but decodes the JSON encoding of the data. It returns an error
+// wrapping ErrInvalidJSON, rather than a *ValidationError, if data is not valid JSON.
+//
+// Example:
+//
+//	payment, err := u.ParseJSON(body, Payment.Schema, u.WithLocale("es"))
+func ParseJSON[T any, S Object](data []byte, schema func(T) S, opts ...Option) (T, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var input any
+	if err := dec.Decode(&input); err != nil {
+		var zero T
+		return zero, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	return parse(input, schema, opts)
+}
+
+// parse decodes input into a value of type T, and validates it with its schema.
+func parse[T any, S Object](input any, schema func(T) S, opts []Option) (T, error) {
+	var value, zero T
+
+	typeErrs := NewValidationError()
+	root := func() *ValidationError { return typeErrs }
+	decode(root, RootTag, root, input, reflect.ValueOf(&value).Elem())
+
+	err := NewSouuup(Bind(value, schema(value))).Validate(opts...)
+	if !typeErrs.HasErrors() {
+		if err != nil {
+			return zero, err
+		}
+		return value, nil
+	}
+
+	ve := NewValidationError()
+	if err != nil {
+		var ok bool
+		if ve, ok = err.(*ValidationError); !ok { //nolint:errorlint // Validate returns the tree itself
+			return zero, err
+		}
+	}
+	// A root of the wrong type is reported first, like the errors of schema rules
+	ve.declareFirst(RootTag)
+	ve.override(typeErrs)
+
+	if o := newOptions(opts); o.locale != "" {
+		ve.Translate(o.translator, o.locale)
+	}
+	return zero, ve
+}
+
+// override replaces the errors of ve with those of other, for every field other has errors for.
+func (ve *ValidationError) override(other *ValidationError) {
+	for _, tag := range other.tags() {
+		if errs := other.Errors[tag]; len(errs) > 0 {
+			ve.Errors[tag] = slices.Clone(errs)
+		}
+		if nested, ok := other.NestedErrors[tag]; ok && nested.HasErrors() {
+			ve.GetOrCreateNested(tag).override(nested)
+		}
+	}
+}
+
+// decode decodes src into dst. Type errors of the value are reported under tag in the
+// ValidationError returned by errs, and those of its properties or elements in the one returned
+// by children. Both are only created when an error is reported.
+func decode(errs func() *ValidationError, tag FieldTag, children func() *ValidationError, src any, dst reflect.Value) {
+	if src == nil {
+		return
+	}
+
+	typeError := func(expected string) {
+		errs().AddError(tag, NewRuleError(CodeType, Params{"expected": expected, "actual": jsonType(src)}))
+	}
+
+	if dst.Kind() != reflect.Pointer && dst.CanAddr() {
+		if unmarshaler, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			s, ok := src.(string)
+			if !ok || unmarshaler.UnmarshalText([]byte(s)) != nil {
+				typeError(dst.Type().String())
+			}
+			return
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		decode(errs, tag, children, src, elem.Elem())
+		dst.Set(elem)
+
+	case reflect.Interface:
+		v := reflect.ValueOf(src)
+		if !v.Type().AssignableTo(dst.Type()) {
+			typeError(dst.Type().String())
+			return
+		}
+		dst.Set(v)
+
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			typeError(typeString)
+			return
+		}
+		dst.SetString(s)
+
+	case reflect.Bool:
+		b, ok := parseBool(src)
+		if !ok {
+			typeError(typeBoolean)
+			return
+		}
+		dst.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := parseInt(src)
+		if !ok || dst.OverflowInt(n) {
+			typeError(typeInteger)
+			return
+		}
+		dst.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := parseUint(src)
+		if !ok || dst.OverflowUint(n) {
+			typeError(typeInteger)
+			return
+		}
+		dst.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := parseFloat(src)
+		if !ok || dst.OverflowFloat(f) {
+			typeError(typeNumber)
+			return
+		}
+		dst.SetFloat(f)
+
+	case reflect.Struct:
+		m, ok := src.(map[string]any)
+		if !ok {
+			typeError(typeObject)
+			return
+		}
+		decodeStruct(children, m, dst)
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := src.([]any)
+		if !ok {
+			typeError(typeArray)
+			return
+		}
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.MakeSlice(dst.Type(), len(arr), len(arr)))
+		}
+		for i := range min(len(arr), dst.Len()) {
+			decode(children, strconv.Itoa(i), nestedIn(children, strconv.Itoa(i)), arr[i], dst.Index(i))
+		}
+
+	case reflect.Map:
+		m, ok := src.(map[string]any)
+		if !ok {
+			typeError(typeObject)
+			return
+		}
+		decodeMap(children, m, dst)
+
+	default:
+		typeError(dst.Type().String())
+	}
+}
+
+// decodeStruct decodes the properties of an object into the exported fields of a struct, by
+// their json names. The fields of embedded structs are promoted, as with encoding/json.
+func decodeStruct(errs func() *ValidationError, m map[string]any, dst reflect.Value) {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+		name, skip := structtag.FieldName(field.Name, field.Tag.Get("json"))
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == field.Name {
+			decodeStruct(errs, m, dst.Field(i))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if value, ok := m[name]; ok {
+			decode(errs, name, nestedIn(errs, name), value, dst.Field(i))
+		}
+	}
+}
+
+// decodeMap decodes the properties of an object into a map, reporting keys that cannot be
+// decoded into the key type of the map under the key.
+func decodeMap(errs func() *ValidationError, m map[string]any, dst reflect.Value) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	out := reflect.MakeMapWithSize(dst.Type(), len(m))
+	for _, key := range keys {
+		k := reflect.New(dst.Type().Key()).Elem()
+		decode(errs, key, nestedIn(errs, key), key, k)
+		if errs().Errors[key] != nil {
+			continue
+		}
+
+		v := reflect.New(dst.Type().Elem()).Elem()
+		decode(errs, key, nestedIn(errs, key), m[key], v)
+		out.SetMapIndex(k, v)
+	}
+	dst.Set(out)
+}
+
+// nestedIn returns a function returning the nested ValidationError of tag in the one returned by errs.
+func nestedIn(errs func() *ValidationError, tag FieldTag) func() *ValidationError {
+	return func() *ValidationError {
+		return errs().GetOrCreateNested(tag)
+	}
+}
+
+// parseBool returns the boolean value of a boolean, or of a string representing one.
+func parseBool(src any) (bool, bool) {
+	switch v := src.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+// parseInt returns the value of an integral number, or of a string representing one.
+func parseInt(src any) (int64, bool) {
+	if s, ok := numberString(src); ok {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, true
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return floatToInt(f, err == nil)
+	}
+
+	rv := reflect.ValueOf(src)
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint():
+		n := rv.Uint()
+		return int64(n), n <= math.MaxInt64 //nolint:gosec // checked for overflow
+	case rv.CanFloat():
+		return floatToInt(rv.Float(), true)
+	default:
+		return 0, false
+	}
+}
+
+// parseUint returns the value of a non-negative integral number, or of a string representing one.
+func parseUint(src any) (uint64, bool) {
+	if s, ok := numberString(src); ok {
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return n, true
+		}
+	}
+
+	n, ok := parseInt(src)
+	return uint64(n), ok && n >= 0 //nolint:gosec // checked for overflow
+}
+
+// parseFloat returns the value of a finite number, or of a string representing one.
+func parseFloat(src any) (float64, bool) {
+	if s, ok := numberString(src); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return finite(f, err == nil)
+	}
+
+	rv := reflect.ValueOf(src)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return finite(rv.Float(), true)
+	default:
+		return 0, false
+	}
+}
+
+// numberString returns the text of a json.Number or of a string that may represent a number.
+func numberString(src any) (string, bool) {
+	switch v := src.(type) {
+	case json.Number:
+		return v.String(), true
+	case string:
+		return strings.TrimSpace(v), true
+	default:
+		return "", false
+	}
+}
+
+// floatToInt returns the value of a float as an integer, if it has no fractional part.
+func floatToInt(f float64, ok bool) (int64, bool) {
+	if !ok || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// finite returns a float if it is neither NaN nor infinite, as strconv.ParseFloat accepts
+// "NaN" and "Inf", which pass every comparison rule.
+func finite(f float64, ok bool) (float64, bool) {
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// jsonType returns the JSON type name of a decoded value, or its Go type for other values.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return typeNull
+	case bool:
+		return typeBoolean
+	case string:
+		return typeString
+	case json.Number:
+		return typeNumber
+	case map[string]any:
+		return typeObject
+	case []any:
+		return typeArray
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.CanInt() || rv.CanUint() || rv.CanFloat() {
+		return typeNumber
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,242 @@
+package u_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cachesdev/souuup/internal/testutil"
+	"github.com/cachesdev/souuup/u"
+)
+
+type customer struct {
+	Email string `json:"email"`
+}
+
+type payment struct {
+	ID        int            `json:"id"`
+	Amount    float64        `json:"amount"`
+	Paid      bool           `json:"paid"`
+	Note      *string        `json:"note"`
+	Tags      []string       `json:"tags"`
+	Limits    map[string]int `json:"limits"`
+	Customer  customer       `json:"customer"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Internal  string         `json:"-"`
+}
+
+func (p payment) Schema() u.Schema {
+	positive := func(fs u.FieldState[float64]) error {
+		if fs.Value <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	notEmpty := func(fs u.FieldState[string]) error {
+		if fs.Value == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}
+
+	return u.Schema{
+		"amount":   u.Field(p.Amount, positive),
+		"customer": u.Schema{"email": u.Field(p.Customer.Email, notEmpty)},
+	}
+}
+
+func TestParse(t *testing.T) {
+	note := "first order"
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		want     payment
+		expected string
+	}{
+		{
+			name: "valid data",
+			data: map[string]any{
+				"id":        float64(7),
+				"amount":    12.5,
+				"paid":      true,
+				"note":      note,
+				"tags":      []any{"a", "b"},
+				"limits":    map[string]any{"daily": 100},
+				"customer":  map[string]any{"email": "john@example.com"},
+				"createdAt": "2025-01-02T03:04:05Z",
+				"Internal":  "ignored",
+			},
+			want: payment{
+				ID:        7,
+				Amount:    12.5,
+				Paid:      true,
+				Note:      &note,
+				Tags:      []string{"a", "b"},
+				Limits:    map[string]int{"daily": 100},
+				Customer:  customer{Email: "john@example.com"},
+				CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name: "strings are coerced to numbers and booleans",
+			data: map[string]any{
+				"id":       "7",
+				"amount":   " 12.5 ",
+				"paid":     "true",
+				"customer": map[string]any{"email": "john@example.com"},
+			},
+			want: payment{ID: 7, Amount: 12.5, Paid: true, Customer: customer{Email: "john@example.com"}},
+		},
+		{
+			name: "null values are zero values",
+			data: map[string]any{
+				"amount":   1.0,
+				"note":     nil,
+				"customer": map[string]any{"email": "john@example.com"},
+			},
+			want: payment{Amount: 1, Customer: customer{Email: "john@example.com"}},
+		},
+		{
+			name: "schema errors",
+			data: map[string]any{"amount": -1.0},
+			expected: `{"amount":{"errors":["must be positive"]},` +
+				`"customer":{"email":{"errors":["is required"]}}}`,
+		},
+		{
+			name: "type errors replace schema errors",
+			data: map[string]any{
+				"amount":   "twelve",
+				"customer": map[string]any{"email": 42},
+			},
+			expected: `{"amount":{"errors":["expected number, got string"]},` +
+				`"customer":{"email":{"errors":["expected string, got number"]}}}`,
+		},
+		{
+			name: "type errors of fields outside the schema",
+			data: map[string]any{
+				"id":       1.5,
+				"amount":   1.0,
+				"tags":     []any{"a", false},
+				"limits":   map[string]any{"daily": "many"},
+				"customer": "john@example.com",
+			},
+			expected: `{"customer":{"errors":["expected object, got string"],"email":{"errors":["is required"]}},` +
+				`"id":{"errors":["expected integer, got number"]},` +
+				`"limits":{"daily":{"errors":["expected integer, got string"]}},` +
+				`"tags":{"1":{"errors":["expected string, got boolean"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := u.Parse(tt.data, payment.Schema)
+
+			// Assert
+			testutil.CheckError(t, err, tt.expected != "", tt.expected)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParse_Overflow(t *testing.T) {
+	// Arrange
+	type counter struct {
+		Count int8 `json:"count"`
+		Total uint `json:"total"`
+	}
+	schema := func(c counter) u.Schema {
+		return u.Schema{"count": u.Field(c.Count), "total": u.Field(c.Total)}
+	}
+
+	// Act
+	_, err := u.Parse(map[string]any{"count": 300, "total": -1}, schema)
+
+	// Assert
+	var ve *u.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	for _, tag := range []u.FieldTag{"count", "total"} {
+		if len(ve.Errors[tag]) != 1 {
+			t.Fatalf("expected 1 error for %s, got %v", tag, ve.Errors[tag])
+		}
+		testutil.CheckRuleError(t, ve.Errors[tag][0], u.CodeType, u.Params{"expected": "integer", "actual": "number"})
+	}
+}
+
+func TestParse_NonFinite(t *testing.T) {
+	for _, amount := range []any{"NaN", "Inf", "-Infinity", math.NaN(), math.Inf(1)} {
+		// Act
+		_, err := u.Parse(map[string]any{"amount": amount, "customer": map[string]any{"email": "john@example.com"}}, payment.Schema)
+
+		// Assert
+		var ve *u.ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected a validation error for %v, got %v", amount, err)
+		}
+		if len(ve.Errors["amount"]) != 1 || ve.Errors["amount"][0].Code != u.CodeType {
+			t.Errorf("expected a type error for %v, got %v", amount, ve.Errors["amount"])
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		opts     []u.Option
+		want     payment
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "valid JSON",
+			data: `{"id": 9007199254740993, "amount": 10, "customer": {"email": "john@example.com"}}`,
+			want: payment{ID: 9007199254740993, Amount: 10, Customer: customer{Email: "john@example.com"}},
+		},
+		{
+			name:     "type errors",
+			data:     `{"amount": true, "customer": {"email": "john@example.com"}}`,
+			expected: `{"amount":{"errors":["expected number, got boolean"]}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "translated type errors",
+			data:     `{"amount": true, "customer": {"email": "john@example.com"}}`,
+			opts:     []u.Option{u.WithLocale("es")},
+			expected: `{"amount":{"errors":["se esperaba number, pero se recibió boolean"]}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "root that is not an object",
+			data:     `[1, 2]`,
+			expected: `{"_errors":{"errors":["expected object, got array"]},"amount":{"errors":["must be positive"]},"customer":{"email":{"errors":["is required"]}}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid JSON",
+			data:     `{"amount":`,
+			expected: "invalid JSON: unexpected EOF",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := u.ParseJSON([]byte(tt.data), payment.Schema, tt.opts...)
+
+			// Assert
+			testutil.CheckError(t, err, tt.wantErr, tt.expected)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
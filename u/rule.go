@@ -1,6 +1,8 @@
 package u
 
 import (
+	"strings"
+
 	"golang.org/x/exp/constraints"
 )
 
@@ -23,3 +25,58 @@ type NumericRule[T Numeric] = Rule[T]
 
 // SliceRule is a specialised rule type for slice validation.
 type SliceRule[T any] = Rule[[]T]
+
+// MapRule is a specialised rule type for map validation.
+type MapRule[K comparable, V any] = Rule[map[K]V]
+
+// applyRules applies rules in order to a field state, as a single rule combining them. It
+// returns every failure, or only the first one failing validation when the validation run
+// short-circuits.
+func applyRules[T any](fs FieldState[T], rules []Rule[T]) error {
+	var errs ruleErrors
+	for _, rule := range rules {
+		if err := rule(fs); err != nil {
+			errs = append(errs, err)
+			if fs.ShortCircuit() && blocking(err) {
+				break
+			}
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// describeRules returns the descriptors of rules.
+func describeRules[T any](rules []Rule[T]) []RuleDescriptor {
+	descs := make([]RuleDescriptor, len(rules))
+	for i, rule := range rules {
+		descs[i] = DescriptorOf(rule)
+	}
+	return descs
+}
+
+// ruleErrors is the error of a rule combining other rules when several of them fail.
+// Fields report each of the failures as an error of their own.
+type ruleErrors []error
+
+// Error returns the messages of the failures separated by semicolons.
+// This implementation satisfies the error interface.
+func (errs ruleErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the failures of the combined rules.
+func (errs ruleErrors) Unwrap() []error {
+	return errs
+}
@@ -0,0 +1,189 @@
+package u
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrValidationCancelled is returned, wrapping the context error, when a validation run is
+// aborted because its context was cancelled or its deadline was exceeded.
+var ErrValidationCancelled = errors.New("validation cancelled")
+
+// run holds the state of a single validation run. It is shared by every node of the
+// ValidationError tree being built and by the FieldStates passed to rules.
+type run struct {
+	ctx context.Context //nolint:containedctx // scoped to a single validation run
+
+	// workers bounds the number of extra goroutines used by the run. It is nil when
+	// the run validates sequentially.
+	workers chan struct{}
+
+	// maxErrors is the number of errors after which the run stops, or 0 for no limit.
+	maxErrors int
+
+	// keepErrors is the number of errors kept once the run ends, or 0 for no limit. It replaces
+	// maxErrors in concurrent runs, where the errors reached first depend on scheduling.
+	keepErrors int
+
+	// bail stops the rules of a field after its first failure.
+	bail bool
+
+	// mu guards every ValidationError of the tree and errorCount while the run is concurrent.
+	mu sync.Mutex
+
+	// errorCount is the number of errors added to the tree.
+	errorCount int
+
+	// root is the value bound to the root of the tree, see Bind.
+	root any
+
+	// data holds the data given to the run with WithData.
+	data map[any]any
+
+	// batches holds the lookups registered during the run, by batch, see Lookup.
+	batches []pendingBatch
+}
+
+// newRun creates the state for a validation run with the given context and options.
+func newRun(ctx context.Context, o options) *run {
+	r := &run{ctx: ctx, maxErrors: o.maxErrors, bail: o.bail, data: o.data}
+	if o.concurrency > 1 {
+		r.workers = make(chan struct{}, o.concurrency)
+		r.keepErrors, r.maxErrors = o.maxErrors, 0
+	}
+	return r
+}
+
+// context returns the context of the run, or context.Background() outside of a run.
+func (r *run) context() context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// rootValue returns the value bound to the root of the tree, or nil outside of a run.
+func (r *run) rootValue() any {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.root
+}
+
+// cancelled reports whether the context of the run is done, in which case
+// no more rules should be run.
+func (r *run) cancelled() bool {
+	return r != nil && r.ctx.Err() != nil
+}
+
+// stopped reports whether no more rules should be run, either because the run was
+// cancelled or because the maximum number of errors was reached.
+func (r *run) stopped() bool {
+	if r == nil {
+		return false
+	}
+	if r.cancelled() {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limitReached()
+}
+
+// limitReached reports whether the maximum number of errors was reached. It must be called
+// with the lock held.
+func (r *run) limitReached() bool {
+	return r.maxErrors > 0 && r.errorCount >= r.maxErrors
+}
+
+// shortCircuit reports whether rules should stop at their first failure, because the rules
+// of a field bail or because a single failure would reach the maximum number of errors.
+func (r *run) shortCircuit() bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bail || r.keepErrors == 1 || (r.maxErrors > 0 && r.maxErrors-r.errorCount <= 1)
+}
+
+// truncate keeps the errors of ve allowed by the limit of a concurrent run, see keepErrors.
+func (r *run) truncate(ve *ValidationError) {
+	if r.keepErrors > 0 {
+		ve.truncate(r.keepErrors)
+	}
+}
+
+// cancellationError returns the error reported when the run was cancelled, or nil.
+func (r *run) cancellationError() error {
+	if !r.cancelled() {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrValidationCancelled, context.Cause(r.ctx))
+}
+
+// lock acquires the lock guarding the error tree of the run, if any.
+func (r *run) lock() {
+	if r != nil {
+		r.mu.Lock()
+	}
+}
+
+// unlock releases the lock guarding the error tree of the run, if any.
+func (r *run) unlock() {
+	if r != nil {
+		r.mu.Unlock()
+	}
+}
+
+// forEach calls fn for every index in [0, n) and waits for all calls to return.
+// When the run is concurrent, calls are made on a free worker if there is one and in
+// the calling goroutine otherwise, so nested calls never wait on each other for a worker.
+func (r *run) forEach(n int, fn func(i int)) {
+	if r == nil || r.workers == nil {
+		for i := range n {
+			fn(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range n {
+		select {
+		case r.workers <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-r.workers
+					wg.Done()
+				}()
+				fn(i)
+			}()
+		default:
+			fn(i)
+		}
+	}
+	wg.Wait()
+}
+
+// ForEach calls fn for every index in [0, n) and waits for all calls to return. When the
+// validation run of fs has concurrency enabled with WithConcurrency, the calls are spread
+// over the run's workers, so fn must be safe for concurrent use. Rules validating the elements
+// of a collection should use it, storing results by index to keep their output deterministic.
+//
+// Example:
+//
+//	errs := make([]error, len(fs.Value))
+//	u.ForEach(fs, len(fs.Value), func(i int) {
+//		errs[i] = rule(u.DeriveAt(fs, strconv.Itoa(i), fs.Value[i]))
+//	})
+func ForEach[T any](fs FieldState[T], n int, fn func(i int)) {
+	fs.run.forEach(n, fn)
+}
@@ -0,0 +1,204 @@
+package u_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestSouuup_Validate_WithConcurrency(t *testing.T) {
+	newSchema := func() u.Schema {
+		failIfOdd := func(fs u.FieldState[int]) error {
+			if fs.Value%2 != 0 {
+				return fmt.Errorf("%d is odd", fs.Value)
+			}
+			return nil
+		}
+
+		schema := u.Schema{}
+		for i := range 50 {
+			nested := u.Schema{}
+			for j := range 20 {
+				nested[fmt.Sprintf("field%d", j)] = u.Field(i+j, failIfOdd, failIfOdd)
+			}
+			schema[fmt.Sprintf("nested%d", i)] = nested
+			schema[fmt.Sprintf("field%d", i)] = u.Field(i, failIfOdd)
+		}
+		return schema
+	}
+
+	t.Run("produces the same errors as a sequential run", func(t *testing.T) {
+		// Arrange
+		sequential := u.NewSouuup(newSchema())
+		concurrent := u.NewSouuup(newSchema())
+
+		// Act
+		sequentialErr := sequential.Validate()
+		concurrentErr := concurrent.Validate(u.WithConcurrency(8))
+
+		// Assert
+		want, err := json.Marshal(sequentialErr)
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		got, err := json.Marshal(concurrentErr)
+		if err != nil {
+			t.Fatalf("unexpected marshalling error: %v", err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("expected concurrent output to match sequential output\nwant: %s\ngot:  %s", want, got)
+		}
+	})
+
+	t.Run("bounds the number of goroutines validating fields", func(t *testing.T) {
+		// Arrange
+		const workers = 4
+		var mu sync.Mutex
+		active, peak := 0, 0
+		slowRule := func(fs u.FieldState[int]) error {
+			mu.Lock()
+			active++
+			peak = max(peak, active)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+			return nil
+		}
+
+		schema := u.Schema{}
+		for i := range 40 {
+			schema[fmt.Sprintf("field%d", i)] = u.Field(i, slowRule)
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate(u.WithConcurrency(workers))
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		// The workers plus the calling goroutine, which validates fields when no worker is free.
+		if peak > workers+1 {
+			t.Errorf("expected at most %d fields validated at once, got %d", workers+1, peak)
+		}
+	})
+}
+
+func TestSouuup_Validate_ErrorLimits(t *testing.T) {
+	alwaysFails := func(fs u.FieldState[int]) error {
+		return fmt.Errorf("failed %d", fs.Value)
+	}
+
+	countErrors := func(ve *u.ValidationError) int {
+		var count func(*u.ValidationError) int
+		count = func(ve *u.ValidationError) int {
+			n := 0
+			for _, errs := range ve.Errors {
+				n += len(errs)
+			}
+			for _, nested := range ve.NestedErrors {
+				n += count(nested)
+			}
+			return n
+		}
+		return count(ve)
+	}
+
+	newSchema := func() u.Schema {
+		return u.Schema{
+			"a": u.Field(1, alwaysFails, alwaysFails, alwaysFails),
+			"b": u.Field(2, alwaysFails, alwaysFails),
+			"nested": u.Schema{
+				"c": u.Field(3, alwaysFails, alwaysFails),
+				"d": u.Field(4, alwaysFails),
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     []u.Option
+		expected int
+	}{
+		{name: "reports every error by default", expected: 8},
+		{name: "fail fast reports a single error", opts: []u.Option{u.WithFailFast()}, expected: 1},
+		{name: "max errors caps the number of errors", opts: []u.Option{u.WithMaxErrors(3)}, expected: 3},
+		{name: "max errors over the total reports every error", opts: []u.Option{u.WithMaxErrors(20)}, expected: 8},
+		{name: "bail reports one error per field", opts: []u.Option{u.WithBail()}, expected: 4},
+		{name: "bail and max errors combine", opts: []u.Option{u.WithBail(), u.WithMaxErrors(2)}, expected: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(newSchema())
+
+			// Act
+			err := s.Validate(tc.opts...)
+
+			// Assert
+			var ve *u.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if n := countErrors(ve); n != tc.expected {
+				t.Errorf("expected %d errors, got %d: %v", tc.expected, n, ve)
+			}
+		})
+	}
+
+	t.Run("limits hold with concurrency", func(t *testing.T) {
+		for _, limit := range []u.Option{u.WithFailFast(), u.WithMaxErrors(3)} {
+			// Arrange
+			want, err := json.Marshal(u.NewSouuup(newSchema()).Validate(limit))
+			if err != nil {
+				t.Fatalf("unexpected marshalling error: %v", err)
+			}
+
+			for range 50 {
+				// Act
+				concurrentErr := u.NewSouuup(newSchema()).Validate(limit, u.WithConcurrency(4))
+
+				// Assert
+				got, err := json.Marshal(concurrentErr)
+				if err != nil {
+					t.Fatalf("unexpected marshalling error: %v", err)
+				}
+				if string(got) != string(want) {
+					t.Fatalf("expected concurrent output to match sequential output\nwant: %s\ngot:  %s", want, got)
+				}
+			}
+		}
+	})
+
+	t.Run("fail fast skips remaining fields", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		counting := func(fs u.FieldState[int]) error {
+			calls++
+			return errors.New("failed")
+		}
+		s := u.NewSouuup(u.Schema{
+			"a": u.Field(1, counting),
+			"b": u.Field(2, counting),
+			"c": u.Schema{"d": u.Field(3, counting)},
+		})
+
+		// Act
+		_ = s.Validate(u.WithFailFast())
+
+		// Assert
+		if calls != 1 {
+			t.Errorf("expected a single rule to run, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package u
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+)
+
+// RootTag is the tag the errors of a schema itself are reported under, rather than those of
+// one of its fields. It is reported before the fields of the schema.
+const RootTag FieldTag = "_errors"
+
+// SchemaState holds the whole value validated by a schema. It is passed to schema rules
+// once the fields of the schema have been validated, to provide access to the value and
+// to the fields that already failed.
+type SchemaState[T any] struct {
+	Value  T
+	errors *ValidationError
+	run    *run
+}
+
+// Path returns the tags leading from the root of the validation run to the schema. It is
+// empty for the root schema.
+func (ss SchemaState[T]) Path() []FieldTag {
+	return ss.errors.Path()
+}
+
+// Context returns the context of the validation run, see FieldState.Context.
+func (ss SchemaState[T]) Context() context.Context {
+	return ss.run.context()
+}
+
+// Failed reports whether any of the fields with the given tags, or their nested fields,
+// failed validation.
+//
+// Example:
+//
+//	if ss.Failed("password") {
+//		return nil // no need to compare an invalid password
+//	}
+func (ss SchemaState[T]) Failed(tags ...FieldTag) bool {
+	return slices.ContainsFunc(ss.FailedFields(), func(tag FieldTag) bool {
+		return slices.Contains(tags, tag)
+	})
+}
+
+// FailedFields returns the tags of the fields of the schema that failed validation, in the
+// order they are reported.
+func (ss SchemaState[T]) FailedFields() []FieldTag {
+	ss.run.lock()
+	defer ss.run.unlock()
+	return ss.errors.tags()
+}
+
+// SchemaRule is a validation rule that takes the whole value validated by a schema and returns
+// an error if validation fails. Errors are reported under RootTag, unless they are attached to
+// fields with ErrorFor. Several errors can be returned at once with errors.Join.
+type SchemaRule[T any] = func(SchemaState[T]) error
+
+// FieldError is an error returned by a schema rule that is reported on fields of the schema
+// rather than on the schema itself.
+type FieldError struct {
+	Tags []FieldTag
+	Err  error
+}
+
+// ErrorFor returns an error that reports err on each of the fields with the given tags when
+// returned by a schema rule. Without tags, err is reported under RootTag.
+//
+// Example:
+//
+//	return u.ErrorFor(errors.New("passwords do not match"), "password", "confirmPassword")
+func ErrorFor(err error, tags ...FieldTag) error {
+	return FieldError{Tags: tags, Err: err}
+}
+
+// Error returns the message of the underlying error.
+// This implementation satisfies the error interface.
+func (fe FieldError) Error() string {
+	return fe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (fe FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// ruledSchema validates an object and then the schema rules of the value it validates.
+type ruledSchema[T any] struct {
+	schema Object
+	value  T
+	rules  []SchemaRule[T]
+}
+
+var (
+	_ Object    = (*ruledSchema[any])(nil)
+	_ Describer = (*ruledSchema[any])(nil)
+)
+
+// WithRules returns an object that validates schema and then applies rules to value, the
+// whole value validated by the schema. Like Bind, value is the parent value of its fields.
+// Rules run in order once every field of the schema has been validated, and are used for
+// checks spanning several fields, such as confirmations.
+//
+// Example:
+//
+//	schema := u.WithRules(u.Schema{
+//		"password":        u.Field(reg.Password, r.MinS(8)),
+//		"confirmPassword": u.Field(reg.ConfirmPassword),
+//	}, reg, func(ss u.SchemaState[UserRegistration]) error {
+//		if ss.Failed("password") || ss.Value.Password == ss.Value.ConfirmPassword {
+//			return nil
+//		}
+//		return u.ErrorFor(errors.New("passwords do not match"), "confirmPassword")
+//	})
+func WithRules[T any](schema Object, value T, rules ...SchemaRule[T]) Object {
+	return &ruledSchema[T]{schema: schema, value: value, rules: rules}
+}
+
+// Validate implements the Validable interface for ruledSchema. Rules are skipped once the
+// validation run is cancelled or reaches its maximum number of errors.
+func (s *ruledSchema[T]) Validate(ve *ValidationError, tag FieldTag) {
+	ve.bind(s.value)
+	ve.declare([]FieldTag{RootTag})
+	s.schema.Validate(ve, tag)
+
+	state := SchemaState[T]{Value: s.value, errors: ve, run: ve.run}
+	for _, rule := range s.rules {
+		if ve.run.stopped() {
+			return
+		}
+
+		err := rule(state)
+		if err == nil || ve.run.cancelled() {
+			continue
+		}
+		addSchemaError(ve, err)
+	}
+}
+
+// addSchemaError reports an error returned by a schema rule, splitting joined errors and
+// reporting field errors on their fields.
+func addSchemaError(ve *ValidationError, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // only the errors joined by the rule itself are split
+		for _, err := range joined.Unwrap() {
+			addSchemaError(ve, err)
+		}
+		return
+	}
+
+	var fe FieldError
+	if !errors.As(err, &fe) || len(fe.Tags) == 0 {
+		ve.AddError(RootTag, err)
+		return
+	}
+	for _, tag := range fe.Tags {
+		ve.AddError(tag, fe.Err)
+	}
+}
+
+// Entries implements the Object interface for ruledSchema, returning the entries of its schema.
+func (s *ruledSchema[T]) Entries() []SchemaEntry {
+	return s.schema.Entries()
+}
+
+// Errors implements the Validable interface for ruledSchema.
+func (s *ruledSchema[T]) Errors() *ValidationError {
+	errors := NewValidationError()
+	s.Validate(errors, "")
+	return errors
+}
+
+// Describe implements the Describer interface for ruledSchema, describing its schema and its
+// schema rules. Schema rules are described by the descriptors registered with RegisterDescriptor.
+func (s *ruledSchema[T]) Describe() FieldDescription {
+	desc := describe(s.schema)
+	for _, rule := range s.rules {
+		desc.Rules = append(desc.Rules, namedDescriptor(reflect.ValueOf(rule).Pointer()))
+	}
+	return desc
+}
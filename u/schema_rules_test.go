@@ -0,0 +1,147 @@
+package u_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+type registration struct {
+	Password        string
+	ConfirmPassword string
+}
+
+func passwordsMatch(ss u.SchemaState[registration]) error {
+	if ss.Value.Password != ss.Value.ConfirmPassword {
+		return u.ErrorFor(errors.New("passwords do not match"), "password", "confirmPassword")
+	}
+	return nil
+}
+
+func TestWithRules(t *testing.T) {
+	notEmpty := func(fs u.FieldState[string]) error {
+		if fs.Value == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}
+	schema := func(reg registration, rules ...u.SchemaRule[registration]) u.Object {
+		return u.WithRules(u.Schema{
+			"password":        u.Field(reg.Password, notEmpty),
+			"confirmPassword": u.Field(reg.ConfirmPassword),
+		}, reg, rules...)
+	}
+
+	tests := []struct {
+		name     string
+		schema   u.Object
+		expected string
+	}{
+		{
+			name:     "passing rules",
+			schema:   schema(registration{Password: "secret", ConfirmPassword: "secret"}, passwordsMatch),
+			expected: "",
+		},
+		{
+			name:   "errors attached to fields",
+			schema: schema(registration{Password: "secret", ConfirmPassword: "other"}, passwordsMatch),
+			expected: `{"confirmPassword":{"errors":["passwords do not match"]},` +
+				`"password":{"errors":["passwords do not match"]}}`,
+		},
+		{
+			name: "errors attached to the schema",
+			schema: schema(registration{Password: "secret"}, func(u.SchemaState[registration]) error {
+				return errors.New("registrations are closed")
+			}),
+			expected: `{"_errors":{"errors":["registrations are closed"]}}`,
+		},
+		{
+			name: "joined errors",
+			schema: schema(registration{Password: "secret"}, func(u.SchemaState[registration]) error {
+				return errors.Join(errors.New("registrations are closed"), u.ErrorFor(errors.New("taken"), "password"))
+			}),
+			expected: `{"_errors":{"errors":["registrations are closed"]},"password":{"errors":["taken"]}}`,
+		},
+		{
+			name: "rules run after field rules",
+			schema: schema(registration{ConfirmPassword: "secret"}, func(ss u.SchemaState[registration]) error {
+				if ss.Failed("password") {
+					return nil
+				}
+				return passwordsMatch(ss)
+			}),
+			expected: `{"password":{"errors":["is required"]}}`,
+		},
+		{
+			name: "nested schema",
+			schema: u.Schema{
+				"account": schema(registration{Password: "secret"}, func(u.SchemaState[registration]) error {
+					return errors.New("invalid account")
+				}),
+			},
+			expected: `{"account":{"_errors":{"errors":["invalid account"]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(tt.schema)
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchemaState_FailedFields(t *testing.T) {
+	// Arrange
+	var failed []u.FieldTag
+	failing := func(u.FieldState[int]) error {
+		return errors.New("failed")
+	}
+	schema := u.WithRules(u.Ordered(
+		u.Entry("b", u.Field(1, failing)),
+		u.Entry("a", u.Field(1)),
+		u.Entry("c", u.Schema{"d": u.Field(1, failing)}),
+	), 0, func(ss u.SchemaState[int]) error {
+		failed = ss.FailedFields()
+		return nil
+	})
+
+	// Act
+	_ = u.NewSouuup(schema).Validate(u.WithConcurrency(4))
+
+	// Assert
+	if !reflect.DeepEqual(failed, []u.FieldTag{"b", "c"}) {
+		t.Errorf("expected the failed fields in reporting order, got %v", failed)
+	}
+}
+
+func TestWithRules_Describe(t *testing.T) {
+	// Arrange
+	schema := u.WithRules(u.Schema{"password": u.Field("secret")}, registration{}, passwordsMatch)
+
+	// Act
+	d, ok := schema.(u.Describer)
+	if !ok {
+		t.Fatal("expected the schema to be a Describer")
+	}
+	desc := d.Describe()
+
+	// Assert
+	if len(desc.Fields) != 1 || len(desc.Rules) != 1 || !desc.Rules[0].Opaque {
+		t.Errorf("expected the schema fields and its opaque rule, got %v", desc)
+	}
+}
@@ -0,0 +1,237 @@
+package u
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Severity is the severity of a rule failure. Only failures of SeverityError fail validation,
+// the others are reported apart from the errors, see Result.
+type Severity string
+
+// Severities of rule failures.
+const (
+	// SeverityError is the severity of failures that fail validation. It is the severity of
+	// failures that do not set one.
+	SeverityError Severity = "error"
+
+	// SeverityWarning is the severity of failures that should be reported to the client, but do
+	// not fail validation, such as a deprecated value.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo is the severity of failures that are only informative.
+	SeverityInfo Severity = "info"
+)
+
+// CodeSeverity is the descriptor name of the rules reporting failures with a severity.
+const CodeSeverity = "rule.severity"
+
+// fails reports whether failures of the severity fail validation.
+func (s Severity) fails() bool {
+	return s != SeverityWarning && s != SeverityInfo
+}
+
+// Warn returns a rule that applies rules in order, reporting their failures as warnings, which
+// do not fail validation. Warnings are returned by Souuup.Check along with the errors.
+//
+// Example:
+//
+//	// A quantity over 100 is suspicious, but allowed
+//	"quantity": u.Field(item.Quantity, r.MinN(1), u.Warn(r.MaxN(100))),
+func Warn[T any](rules ...Rule[T]) Rule[T] {
+	return withSeverity(SeverityWarning, rules)
+}
+
+// Info returns a rule that applies rules in order, reporting their failures as informative
+// notices, which do not fail validation. Notices are returned by Souuup.Check along with the errors.
+//
+// Example:
+//
+//	"country": u.Field(addr.Country, u.Info(r.NotInS([]string{"UK"}))),
+func Info[T any](rules ...Rule[T]) Rule[T] {
+	return withSeverity(SeverityInfo, rules)
+}
+
+// withSeverity returns a rule that applies rules, reporting their failures with severity.
+func withSeverity[T any](severity Severity, rules []Rule[T]) Rule[T] {
+	return WithDescriptor(NewRuleDescriptor(CodeSeverity, Params{"severity": severity, "rules": describeRules(rules)}),
+		func(fs FieldState[T]) error {
+			if err := applyRules(fs, rules); err != nil {
+				return setSeverity(err, severity)
+			}
+			return nil
+		})
+}
+
+// setSeverity returns the failure err with the given severity, including every failure it combines.
+func setSeverity(err error, severity Severity) error {
+	if errs, ok := err.(ruleErrors); ok { //nolint:errorlint // only the errors combined by applyRules are split
+		result := make(ruleErrors, len(errs))
+		for i, err := range errs {
+			result[i] = setSeverity(err, severity)
+		}
+		return result
+	}
+
+	var nested *ValidationError
+	if errors.As(err, &nested) {
+		return nested.withSeverity(severity)
+	}
+
+	re := AsRuleError(err)
+	re.Severity = severity
+	return re
+}
+
+// blocking reports whether the failure err fails validation, which is the case if any of the
+// failures it combines does.
+func blocking(err error) bool {
+	if errs, ok := err.(ruleErrors); ok { //nolint:errorlint // only the errors combined by applyRules are split
+		return slices.ContainsFunc(errs, blocking)
+	}
+
+	var nested *ValidationError
+	if errors.As(err, &nested) {
+		return nested.blocking()
+	}
+	return AsRuleError(err).Severity.fails()
+}
+
+// blocking reports whether the tree has a failure that fails validation, at any level. Trees
+// returned by rules can hold failures of any severity, such as those of a rule wrapped by Warn.
+func (ve *ValidationError) blocking() bool {
+	for _, errs := range ve.Errors {
+		if slices.ContainsFunc(errs, func(re RuleError) bool { return re.Severity.fails() }) {
+			return true
+		}
+	}
+	for _, nested := range ve.NestedErrors {
+		if nested.blocking() {
+			return true
+		}
+	}
+	return false
+}
+
+// withSeverity returns a copy of the tree where every error has the given severity. It is
+// used for the nested errors returned by rules, which are added to a tree once returned.
+func (ve *ValidationError) withSeverity(severity Severity) *ValidationError {
+	result := NewValidationError()
+	result.declare(ve.order)
+	for tag, errs := range ve.Errors {
+		for _, re := range errs {
+			re.Severity = severity
+			result.Errors[tag] = append(result.Errors[tag], re)
+		}
+	}
+	for tag, nested := range ve.NestedErrors {
+		result.NestedErrors[tag] = nested.withSeverity(severity)
+	}
+	return result
+}
+
+// extract returns a tree with the failures of ve, and of its nested errors, that have the given
+// severity and do not fail validation, or nil if there are none.
+func (ve *ValidationError) extract(severity Severity) *ValidationError {
+	result := NewValidationError()
+	result.declare(ve.order)
+	for tag, errs := range ve.notices {
+		for _, re := range errs {
+			if re.Severity == severity {
+				result.Errors[tag] = append(result.Errors[tag], re)
+			}
+		}
+	}
+	for tag, nested := range ve.NestedErrors {
+		if extracted := nested.extract(severity); extracted != nil {
+			extracted.Parent = result
+			extracted.tag = tag
+			result.NestedErrors[tag] = extracted
+		}
+	}
+
+	if !result.HasErrors() {
+		return nil
+	}
+	return result
+}
+
+// Result is the outcome of a validation run. It holds the failures of the run by severity, each
+// of them in a tree with the same shape as a ValidationError, and nil if there are none.
+//
+// Example JSON output:
+//
+//	{
+//	  "errors": {"username": {"errors": [...]}},
+//	  "warnings": {"items": {"0": {"quantity": {"errors": [...]}}}}
+//	}
+type Result struct {
+	// Errors holds the failures that fail validation.
+	Errors *ValidationError `json:"errors,omitempty"`
+
+	// Warnings holds the failures reported as warnings, see Warn.
+	Warnings *ValidationError `json:"warnings,omitempty"`
+
+	// Infos holds the failures reported as informative notices, see Info.
+	Infos *ValidationError `json:"infos,omitempty"`
+}
+
+// Valid reports whether the validation run has no failures that fail validation.
+func (r *Result) Valid() bool {
+	return r.Errors == nil
+}
+
+// Err returns the errors of the validation run as an error, or nil if it is valid.
+func (r *Result) Err() error {
+	if r.Errors == nil {
+		return nil
+	}
+	return r.Errors
+}
+
+// Check performs validation against the schema, like Validate, and returns every failure by
+// severity. The returned error is only set if the validation run was cancelled, or if the
+// loader of a Batch failed, see Lookup.
+//
+// Example:
+//
+//	res, _ := s.Check()
+//	if !res.Valid() {
+//		w.WriteHeader(http.StatusBadRequest)
+//	}
+//	json.NewEncoder(w).Encode(res) // {"errors":{...},"warnings":{...}}
+func (s *Souuup) Check(opts ...Option) (*Result, error) {
+	return s.CheckContext(context.Background(), opts...)
+}
+
+// CheckContext is like Check, but the context is made available to every rule, see ValidateContext.
+func (s *Souuup) CheckContext(ctx context.Context, opts ...Option) (*Result, error) {
+	o := newOptions(opts)
+
+	ve := NewValidationError()
+	ve.run = newRun(ctx, o)
+	s.schema.Validate(ve, "")
+	lookupErr := ve.run.resolveLookups(ve)
+	ve.run.truncate(ve)
+
+	if err := ve.run.cancellationError(); err != nil {
+		return nil, err
+	}
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	if o.locale != "" {
+		ve.Translate(o.translator, o.locale)
+	}
+
+	res := &Result{
+		Warnings: ve.extract(SeverityWarning),
+		Infos:    ve.extract(SeverityInfo),
+	}
+	if ve.HasErrors() {
+		res.Errors = ve
+	}
+	return res, nil
+}
@@ -0,0 +1,210 @@
+package u_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestCheck(t *testing.T) {
+	atMost := func(n int) u.Rule[int] {
+		return func(fs u.FieldState[int]) error {
+			if fs.Value > n {
+				return fmt.Errorf("must be at most %d", n)
+			}
+			return nil
+		}
+	}
+	deprecated := func(fs u.FieldState[string]) error {
+		if fs.Value == "UK" {
+			return errors.New("is deprecated, use GB")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		schema   u.Object
+		opts     []u.Option
+		errors   string
+		warnings string
+		infos    string
+	}{
+		{
+			name: "warnings do not fail validation",
+			schema: u.Schema{
+				"quantity": u.Field(150, u.Warn(atMost(100))),
+				"country":  u.Field("UK", u.Info(deprecated)),
+			},
+			warnings: `{"quantity":{"errors":["must be at most 100"]}}`,
+			infos:    `{"country":{"errors":["is deprecated, use GB"]}}`,
+		},
+		{
+			name: "errors and warnings of the same field",
+			schema: u.Schema{
+				"quantity": u.Field(1500, u.Warn(atMost(100)), atMost(1000)),
+			},
+			errors:   `{"quantity":{"errors":["must be at most 1000"]}}`,
+			warnings: `{"quantity":{"errors":["must be at most 100"]}}`,
+		},
+		{
+			name: "warnings of nested fields",
+			schema: u.Schema{
+				"items": u.Each([]int{1, 150}, func(qty int) u.Schema {
+					return u.Schema{"quantity": u.Field(qty, u.Warn(atMost(100)))}
+				}),
+			},
+			warnings: `{"items":{"1":{"quantity":{"errors":["must be at most 100"]}}}}`,
+		},
+		{
+			name: "warnings do not stop bailing fields",
+			schema: u.Schema{
+				"quantity": u.Field(1500, u.Warn(atMost(100)), atMost(1000), atMost(500)),
+			},
+			opts:     []u.Option{u.WithBail()},
+			errors:   `{"quantity":{"errors":["must be at most 1000"]}}`,
+			warnings: `{"quantity":{"errors":["must be at most 100"]}}`,
+		},
+		{
+			name: "warnings do not count towards the maximum number of errors",
+			schema: u.Ordered(
+				u.Entry("a", u.Field(150, u.Warn(atMost(100)))),
+				u.Entry("b", u.Field(1500, atMost(1000))),
+			),
+			opts:     []u.Option{u.WithMaxErrors(1)},
+			errors:   `{"b":{"errors":["must be at most 1000"]}}`,
+			warnings: `{"a":{"errors":["must be at most 100"]}}`,
+		},
+		{
+			name: "warnings of rules with nested errors",
+			schema: u.Schema{
+				"limits": u.Field(map[string]int{"daily": 150}, u.Warn(func(fs u.FieldState[map[string]int]) error {
+					errs := u.NewValidationError()
+					errs.AddError("daily", atMost(100)(u.Derive(fs, fs.Value["daily"])))
+					return errs
+				})),
+			},
+			warnings: `{"limits":{"daily":{"errors":["must be at most 100"]}}}`,
+		},
+		{
+			name: "warnings of rules with nested errors do not stop bailing fields",
+			schema: u.Schema{
+				"limits": u.Field(map[string]int{"daily": 1500}, u.Warn(func(fs u.FieldState[map[string]int]) error {
+					errs := u.NewValidationError()
+					errs.AddError("daily", atMost(100)(u.Derive(fs, fs.Value["daily"])))
+					return errs
+				}), func(fs u.FieldState[map[string]int]) error {
+					return atMost(1000)(u.Derive(fs, fs.Value["daily"]))
+				}),
+			},
+			opts:     []u.Option{u.WithBail()},
+			errors:   `{"limits":{"errors":["must be at most 1000"]}}`,
+			warnings: `{"limits":{"daily":{"errors":["must be at most 100"]}}}`,
+		},
+		{
+			name: "warnings of schema rules",
+			schema: u.WithRules(u.Schema{"quantity": u.Field(1)}, 1, func(u.SchemaState[int]) error {
+				return u.RuleError{Message: "orders of a single item are unusual", Severity: u.SeverityWarning}
+			}),
+			warnings: `{"_errors":{"errors":["orders of a single item are unusual"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(tt.schema)
+
+			// Act
+			res, err := s.Check(tt.opts...)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Valid() != (tt.errors == "") {
+				t.Errorf("expected valid: %v, got: %v", tt.errors == "", res.Valid())
+			}
+			for name, got := range map[string][2]string{
+				"errors":   {messagesOf(res.Errors), tt.errors},
+				"warnings": {messagesOf(res.Warnings), tt.warnings},
+				"infos":    {messagesOf(res.Infos), tt.infos},
+			} {
+				if got[0] != got[1] {
+					t.Errorf("expected %s %s, got %s", name, got[1], got[0])
+				}
+			}
+		})
+	}
+}
+
+// messagesOf returns the messages of a tree of failures, or an empty string if there are none.
+func messagesOf(ve *u.ValidationError) string {
+	if ve == nil {
+		return ""
+	}
+	return ve.Error()
+}
+
+func TestValidate_Warnings(t *testing.T) {
+	// Arrange
+	s := u.NewSouuup(u.Schema{
+		"country": u.Field("UK", u.Warn(func(u.FieldState[string]) error {
+			return errors.New("is deprecated")
+		})),
+	})
+
+	// Act
+	err := s.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestResult_MarshalJSON(t *testing.T) {
+	// Arrange
+	s := u.NewSouuup(u.Ordered(
+		u.Entry("country", u.Field("UK", u.Warn(func(fs u.FieldState[string]) error {
+			return u.NewRuleError("string.deprecated", u.Params{"actual": fs.Value})
+		}))),
+		u.Entry("quantity", u.Field(0, func(u.FieldState[int]) error {
+			return errors.New("is required")
+		})),
+	))
+	res, _ := s.Check()
+
+	// Act
+	data, err := json.Marshal(res)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"errors":{"quantity":{"errors":[{"message":"is required"}]}},` +
+		`"warnings":{"country":{"errors":[{"code":"string.deprecated","params":{"actual":"UK"},"message":"string.deprecated","severity":"warning"}]}}}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestWarn_Descriptor(t *testing.T) {
+	// Arrange
+	rule := u.Warn(u.WithDescriptor(u.RuleDescriptor{Name: "number.max", Description: "at most 100"},
+		func(u.FieldState[int]) error { return nil }))
+
+	// Act
+	desc := u.DescriptorOf(rule)
+
+	// Assert
+	if desc.Name != u.CodeSeverity {
+		t.Errorf("expected name %q, got %q", u.CodeSeverity, desc.Name)
+	}
+	if expected := "reported as a warning: at most 100"; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
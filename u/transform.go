@@ -0,0 +1,80 @@
+package u
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CodeTransform is the descriptor name of the rules returned by Transform.
+const CodeTransform = "rule.transform"
+
+// transformRules holds the code pointers of the rules returned by Transform.
+var transformRules sync.Map
+
+// Transform returns a rule that replaces the value of a field with the result of applying fns
+// to it, in order. The transforms of a field run before its other rules, in the order they are
+// given, so the rules validate the transformed value. After validation, the transformed value
+// is returned by FieldDef.Value, so callers can store exactly what was validated.
+//
+// Transforms only apply to the value of a field. Within other rules, such as r.Every or When,
+// they have no effect.
+//
+// Example:
+//
+//	email := u.Field(req.Email, u.Transform(strings.TrimSpace, strings.ToLower), r.MinS(3), ValidEmail)
+//	err := u.NewSouuup(u.Schema{"email": email}).Validate()
+//	...
+//	user.Email = email.Value() // trimmed and lowercased
+func Transform[T any](fns ...func(T) T) Rule[T] {
+	names := make([]string, len(fns))
+	for i, fn := range fns {
+		names[i] = funcName(reflect.ValueOf(fn).Pointer())
+	}
+	desc := NewRuleDescriptor(CodeTransform, Params{"funcs": strings.Join(names, ", ")})
+
+	transform := func(fs FieldState[T]) error {
+		switch {
+		case fs.describe != nil:
+			*fs.describe = desc
+		case fs.transformed != nil:
+			value := fs.Value
+			for _, fn := range fns {
+				value = fn(value)
+			}
+			*fs.transformed = value
+		}
+		return nil
+	}
+
+	ptr := reflect.ValueOf(transform).Pointer()
+	if _, ok := transformRules.Load(ptr); !ok {
+		transformRules.Store(ptr, struct{}{})
+		describedRules.Store(ptr, struct{}{})
+	}
+	return transform
+}
+
+// isTransform reports whether a rule was returned by Transform.
+func isTransform[T any](rule Rule[T]) bool {
+	_, ok := transformRules.Load(reflect.ValueOf(rule).Pointer())
+	return ok
+}
+
+// splitTransforms splits rules into the transforms and the other rules, keeping their order.
+func splitTransforms[T any](rules []Rule[T]) ([]Rule[T], []Rule[T]) {
+	if !slices.ContainsFunc(rules, isTransform[T]) {
+		return nil, rules
+	}
+
+	var transforms, checks []Rule[T]
+	for _, rule := range rules {
+		if isTransform(rule) {
+			transforms = append(transforms, rule)
+		} else {
+			checks = append(checks, rule)
+		}
+	}
+	return transforms, checks
+}
@@ -0,0 +1,98 @@
+package u_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cachesdev/souuup/u"
+)
+
+func TestTransform(t *testing.T) {
+	minLength := func(fs u.FieldState[string]) error {
+		if len(fs.Value) < 3 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		field    *u.FieldDef[string]
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "transforms run before rules",
+			field:    u.Field("  ab  ", u.Transform(strings.TrimSpace), minLength),
+			expected: "ab",
+			wantErr:  true,
+		},
+		{
+			name:     "transforms run before rules given before them",
+			field:    u.Field("  ab  ", minLength, u.Transform(strings.TrimSpace)),
+			expected: "ab",
+			wantErr:  true,
+		},
+		{
+			name:     "transforms run in order",
+			field:    u.Field("  John@Example.COM ", u.Transform(strings.TrimSpace), u.Transform(strings.ToLower, strings.ToUpper)),
+			expected: "JOHN@EXAMPLE.COM",
+		},
+		{
+			name:     "fields without transforms keep their value",
+			field:    u.Field(" abc ", minLength),
+			expected: " abc ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := u.NewSouuup(u.Schema{"field": tt.field})
+
+			// Act
+			err := s.Validate()
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if got := tt.field.Value(); got != tt.expected {
+				t.Errorf("expected value %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTransform_Revalidate(t *testing.T) {
+	// Arrange
+	calls := 0
+	field := u.Field(1, u.Transform(func(v int) int {
+		calls++
+		return v * 2
+	}))
+	s := u.NewSouuup(u.Schema{"field": field})
+
+	// Act
+	_ = s.Validate()
+	_ = s.Validate()
+
+	// Assert
+	if field.Value() != 2 || calls != 2 {
+		t.Errorf("expected the original value to be transformed on every validation, got %d after %d calls", field.Value(), calls)
+	}
+}
+
+func TestTransform_Descriptor(t *testing.T) {
+	// Act
+	desc := u.DescriptorOf(u.Transform(strings.TrimSpace, strings.ToLower))
+
+	// Assert
+	if desc.Name != u.CodeTransform {
+		t.Errorf("expected name %q, got %q", u.CodeTransform, desc.Name)
+	}
+	if expected := "is transformed with strings.TrimSpace, strings.ToLower"; desc.Description != expected {
+		t.Errorf("expected description %q, got %q", expected, desc.Description)
+	}
+}
@@ -24,29 +24,61 @@
 // to complex API request validation.
 package u
 
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 // FieldTag represents the "key" of a field, and will be used to identify a field on
 // an error map and schema
 type FieldTag = string
 
-// souuupState contains the internal state for a Souuup validator instance.
-// This includes any validation errors that have been accumulated.
-type souuupState struct {
-	errors *ValidationError
-}
-
 // Schema is a map of field tags to validatable entities.
 // It can contain both simple fields and nested schemas, allowing for
 // the validation of complex, hierarchical data structures.
-// Schema itself implements the Validable interface.
+// Its fields are validated and reported in lexical order of their tags.
+// Schema itself implements the Validable and Object interfaces.
 type Schema map[FieldTag]Validable
 
-var _ Validable = (*Schema)(nil)
+var (
+	_ Object    = (*Schema)(nil)
+	_ Describer = (*Schema)(nil)
+)
+
+// SchemaEntry is a field, or nested schema, of an OrderedSchema.
+type SchemaEntry struct {
+	Tag   FieldTag
+	Field Validable
+}
+
+// OrderedSchema is a schema that validates and reports its fields in declaration order.
+// It is otherwise equivalent to Schema, and both can be nested in each other.
+// OrderedSchema implements the Validable and Object interfaces.
+type OrderedSchema []SchemaEntry
+
+var (
+	_ Object    = (*OrderedSchema)(nil)
+	_ Describer = (*OrderedSchema)(nil)
+)
+
+// Object is implemented by validatable entities that group fields under tags, such as
+// Schema and OrderedSchema. When nested in a schema, an object is validated against its
+// own nested ValidationError.
+type Object interface {
+	Validable
+
+	// Entries returns the fields of the object in the order they are validated and reported.
+	Entries() []SchemaEntry
+}
 
 // Souuup is the main validator instance.
-// It holds a validation schema and internal state.
+// It holds a validation schema, and every validation builds its own errors, so it can be
+// validated many times, and from several goroutines at once. To validate many values with
+// the same schema definition, see Compile.
 type Souuup struct {
-	state  *souuupState
-	schema Schema
+	schema Object
 }
 
 // NewSouuup creates a new validator instance with the provided schema.
@@ -59,47 +91,228 @@ type Souuup struct {
 //		"age":      u.Field(25, u.MinN(18)),
 //	}
 //	s := u.NewSouuup(schema)
-func NewSouuup(schema Schema) *Souuup {
-	return &Souuup{
-		state:  &souuupState{NewValidationError()},
-		schema: schema,
-	}
+func NewSouuup(schema Object) *Souuup {
+	return &Souuup{schema: schema}
 }
 
 // Validate performs validation against the schema and returns an error if validation fails.
-// If validation succeeds, it returns nil.
+// If validation succeeds, it returns nil. Warnings do not make Validate fail; use Check to get
+// them. Options can be provided to configure this run, such as the locale the error messages
+// are rendered in.
 //
 // Example:
 //
-//	err := s.Validate()
+//	err := s.Validate(u.WithLocale("es"))
 //	if err != nil {
 //		fmt.Println("Validation failed:", err)
 //		return
 //	}
-func (s *Souuup) Validate() error {
-	s.schema.Validate(s.state.errors, "")
+func (s *Souuup) Validate(opts ...Option) error {
+	return s.ValidateContext(context.Background(), opts...)
+}
 
-	if s.state.errors.HasErrors() {
-		return s.state.errors
+// ValidateContext is like Validate, but the context is made available to every rule through
+// FieldState.Context. If the context is cancelled or its deadline is exceeded, the remaining
+// rules are skipped and an error wrapping both ErrValidationCancelled and the context error
+// is returned instead of the validation errors. Likewise, if the loader of a Batch fails, an
+// error wrapping both ErrLookupFailed and the loader error is returned, see Lookup.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+//	defer cancel()
+//
+//	err := s.ValidateContext(ctx)
+//	if errors.Is(err, u.ErrValidationCancelled) {
+//		http.Error(w, "validation timed out", http.StatusServiceUnavailable)
+//		return
+//	}
+func (s *Souuup) ValidateContext(ctx context.Context, opts ...Option) error {
+	res, err := s.CheckContext(ctx, opts...)
+	if err != nil {
+		return err
 	}
-	return nil
+	return res.Err()
 }
 
 // Validate implements the Validable interface for Schema.
 // It validates all fields and nested schemas within the current schema,
 // adding any validation errors to the provided ValidationError object.
+// When the validation run is concurrent, fields and nested schemas are validated in parallel.
 func (s Schema) Validate(ve *ValidationError, _ FieldTag) {
-	for tag, fieldOrSchema := range s {
-		if schema, ok := fieldOrSchema.(Schema); ok {
-			newVe := NewValidationError()
-			newVe.Parent = ve
+	validateEntries(ve, s.Entries())
+}
+
+// Entries implements the Object interface for Schema, returning its fields sorted by tag.
+func (s Schema) Entries() []SchemaEntry {
+	entries := make([]SchemaEntry, 0, len(s))
+	for tag, field := range s {
+		entries = append(entries, SchemaEntry{Tag: tag, Field: field})
+	}
+	slices.SortFunc(entries, func(a, b SchemaEntry) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return entries
+}
+
+// Ordered creates an OrderedSchema from entries, which are validated and reported in the
+// order they are given.
+//
+// Example:
+//
+//	schema := u.Ordered(
+//		u.Entry("username", u.Field(user.Name, r.MinS(3))),
+//		u.Entry("address", u.Ordered(
+//			u.Entry("street", u.Field(user.Address.Street, r.NotZero)),
+//			u.Entry("city", u.Field(user.Address.City, r.NotZero)),
+//		)),
+//	)
+func Ordered(entries ...SchemaEntry) OrderedSchema {
+	return OrderedSchema(entries)
+}
+
+// Entry creates a SchemaEntry for an OrderedSchema.
+func Entry(tag FieldTag, field Validable) SchemaEntry {
+	return SchemaEntry{Tag: tag, Field: field}
+}
+
+// Each creates an object validating every element of items with the entity returned by schema
+// for it, under the index of the element. Failures are reported in the nested errors of the
+// field keyed by index, so they can be traced back to the element, e.g. /items/1/quantity.
+// The schema of each element is bound to it, see Bind. Combine it with WithNested to also
+// validate the slice itself. It is described as a slice of T, whose elements are described by
+// the entity returned by schema for the zero value of T.
+//
+// Example:
+//
+//	itemSchema := func(item OrderItem) u.Schema {
+//		return u.Schema{
+//			"productID": u.Field(item.ProductID, r.NotZero),
+//			"quantity":  u.Field(item.Quantity, r.MinN(1)),
+//		}
+//	}
+//	schema := u.Schema{
+//		"items": u.WithNested(u.Field(order.Items, r.MinLen[OrderItem](1)), u.Each(order.Items, itemSchema)),
+//	}
+//	// {"items":{"1":{"quantity":{"errors":["value is 0, but needs to be at least 1"]}}}}
+func Each[T any, V Validable](items []T, schema func(T) V) Object {
+	entries := make(OrderedSchema, len(items))
+	for i, item := range items {
+		var v Validable = schema(item)
+		if obj, ok := v.(Object); ok {
+			v = Bind(item, obj)
+		}
+		entries[i] = Entry(strconv.Itoa(i), v)
+	}
+	return &eachSchema[T]{OrderedSchema: entries, elem: func(item T) Validable { return schema(item) }}
+}
+
+// eachSchema validates the elements of a slice, see Each.
+type eachSchema[T any] struct {
+	OrderedSchema
+
+	// elem returns the entity validating an element.
+	elem func(T) Validable
+}
+
+var (
+	_ Object    = (*eachSchema[any])(nil)
+	_ Describer = (*eachSchema[any])(nil)
+)
+
+// nestedField validates the rules of a field and a nested object under the same tag.
+type nestedField struct {
+	field  Validable
+	nested Object
+}
+
+var (
+	_ Validable = (*nestedField)(nil)
+	_ Describer = (*nestedField)(nil)
+)
+
+// WithNested returns a validatable entity that validates field under a tag, and nested as the
+// nested errors of the same tag. It is used for fields that have rules of their own and
+// contain nested fields, such as a slice of structs that needs at least one element.
+//
+// Example:
+//
+//	items := u.Ordered()
+//	for i, item := range order.Items {
+//		items = append(items, u.Entry(strconv.Itoa(i), itemSchema(item)))
+//	}
+//	schema := u.Schema{
+//		"items": u.WithNested(u.Field(order.Items, r.MinLen[Item](1)), items),
+//	}
+func WithNested(field Validable, nested Object) Validable {
+	return &nestedField{field: field, nested: nested}
+}
+
+// Validate implements the Validable interface for nestedField.
+func (f *nestedField) Validate(ve *ValidationError, tag FieldTag) {
+	f.field.Validate(ve, tag)
+	if ve.run.stopped() {
+		return
+	}
+	f.nested.Validate(ve.GetOrCreateNested(tag), tag)
+}
+
+// Errors implements the Validable interface for nestedField.
+func (f *nestedField) Errors() *ValidationError {
+	return f.field.Errors()
+}
+
+// Validate implements the Validable interface for OrderedSchema.
+// It validates all fields and nested schemas within the current schema in declaration order,
+// adding any validation errors to the provided ValidationError object.
+// When the validation run is concurrent, fields and nested schemas are validated in parallel,
+// and the errors are still reported in declaration order.
+func (s OrderedSchema) Validate(ve *ValidationError, _ FieldTag) {
+	validateEntries(ve, s.Entries())
+}
+
+// Entries implements the Object interface for OrderedSchema.
+func (s OrderedSchema) Entries() []SchemaEntry {
+	return s
+}
+
+// Errors returns all validation errors for this schema.
+// It creates a new ValidationError, validates the schema against it,
+// and returns the resulting errors.
+func (s OrderedSchema) Errors() *ValidationError {
+	errors := NewValidationError()
+	s.Validate(errors, "")
+	return errors
+}
+
+// validateEntries validates the entries of an object against ve, in parallel when the
+// validation run is concurrent. Nested objects are validated against a new nested ValidationError.
+func validateEntries(ve *ValidationError, entries []SchemaEntry) {
+	tags := make([]FieldTag, len(entries))
+	for i, entry := range entries {
+		tags[i] = entry.Tag
+	}
+	ve.declare(tags)
+
+	ve.run.forEach(len(entries), func(i int) {
+		if ve.run.stopped() {
+			return
+		}
+
+		tag, fieldOrSchema := entries[i].Tag, entries[i].Field
+		if schema, ok := fieldOrSchema.(Object); ok {
+			newVe := ve.newNested(tag)
+
+			ve.run.lock()
 			ve.NestedErrors[tag] = newVe
+			ve.run.unlock()
+
 			schema.Validate(newVe, tag)
 		} else {
 			field := fieldOrSchema
 			field.Validate(ve, tag)
 		}
-	}
+	})
 }
 
 // Errors returns all validation errors for this schema.
@@ -113,7 +326,7 @@ func (s Schema) Errors() *ValidationError {
 
 // Validable is the interface that must be implemented by any validatable entity.
 // It provides methods to validate the entity and retrieve validation errors.
-// Both Schema and FieldDef implement this interface.
+// Schema, OrderedSchema and FieldDef implement this interface.
 type Validable interface {
 	// Validate validates the entity against a ValidationError object
 	// and associates any errors with the provided field tag.
@@ -1,6 +1,8 @@
 package u_test
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -455,6 +457,263 @@ func TestSouuup_Validate(t *testing.T) {
 	})
 }
 
+func TestSouuup_ValidateContext(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("exposes the context to rules in nested schemas", func(t *testing.T) {
+		// Arrange
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+		var got any
+		schema := u.Schema{
+			"parent": u.Schema{
+				"child": u.Field("value", func(fs u.FieldState[string]) error {
+					got = fs.Context().Value(ctxKey{})
+					return nil
+				}),
+			},
+		}
+
+		// Act
+		err := u.NewSouuup(schema).ValidateContext(ctx)
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if got != "request" {
+			t.Errorf("expected rule to receive the validation context, got value %v", got)
+		}
+	})
+
+	t.Run("returns a cancellation error for an already cancelled context", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		field := &mockValidable{hasErrors: true}
+		schema := u.Schema{"field": field}
+
+		// Act
+		err := u.NewSouuup(schema).ValidateContext(ctx)
+
+		// Assert
+		if !errors.Is(err, u.ErrValidationCancelled) {
+			t.Errorf("expected ErrValidationCancelled, got %v", err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected error to wrap context.Canceled, got %v", err)
+		}
+		if field.validateCalled {
+			t.Error("expected Validate() not to be called after cancellation")
+		}
+	})
+
+	t.Run("aborts remaining rules when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		laterRuleCalled := false
+		schema := u.Schema{
+			"field": u.Field(1,
+				func(fs u.FieldState[int]) error {
+					cancel()
+					return fs.Context().Err()
+				},
+				func(fs u.FieldState[int]) error {
+					laterRuleCalled = true
+					return nil
+				},
+			),
+		}
+
+		// Act
+		err := u.NewSouuup(schema).ValidateContext(ctx)
+
+		// Assert
+		var ve *u.ValidationError
+		if errors.As(err, &ve) {
+			t.Errorf("expected a cancellation error rather than field failures, got %v", err)
+		}
+		if !errors.Is(err, u.ErrValidationCancelled) {
+			t.Errorf("expected ErrValidationCancelled, got %v", err)
+		}
+		if laterRuleCalled {
+			t.Error("expected remaining rules to be skipped after cancellation")
+		}
+	})
+
+	t.Run("reports deadline exceeded", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+
+		// Act
+		err := u.NewSouuup(u.Schema{"field": u.Field(1)}).ValidateContext(ctx)
+
+		// Assert
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected error to wrap context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestOrderedSchema_Validate(t *testing.T) {
+	newSchema := func() u.OrderedSchema {
+		return u.Ordered(
+			u.Entry("username", &mockValidable{hasErrors: true, errorMessage: "username error"}),
+			u.Entry("email", &mockValidable{hasErrors: true, errorMessage: "email error"}),
+			u.Entry("address", u.Ordered(
+				u.Entry("street", &mockValidable{hasErrors: true, errorMessage: "street error"}),
+				u.Entry("city", &mockValidable{hasErrors: true, errorMessage: "city error"}),
+			)),
+			u.Entry("age", &mockValidable{hasErrors: true, errorMessage: "age error"}),
+		)
+	}
+	expected := `{"username":{"errors":["username error"]},"email":{"errors":["email error"]},` +
+		`"address":{"street":{"errors":["street error"]},"city":{"errors":["city error"]}},` +
+		`"age":{"errors":["age error"]}}`
+
+	t.Run("reports errors in declaration order", func(t *testing.T) {
+		// Arrange
+		s := u.NewSouuup(newSchema())
+
+		// Act
+		err := s.Validate()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), expected)
+	})
+
+	t.Run("reports errors in declaration order with concurrency", func(t *testing.T) {
+		for range 20 {
+			// Arrange
+			s := u.NewSouuup(newSchema())
+
+			// Act
+			err := s.Validate(u.WithConcurrency(4))
+
+			// Assert
+			if err == nil {
+				t.Fatal("expected validation error, got nil")
+			}
+			assertErrorMessage(t, err.Error(), expected)
+		}
+	})
+
+	t.Run("validates fields in declaration order", func(t *testing.T) {
+		// Arrange
+		var validated []string
+		record := func(name string) u.Rule[int] {
+			return func(fs u.FieldState[int]) error {
+				validated = append(validated, name)
+				return nil
+			}
+		}
+		schema := u.Ordered(
+			u.Entry("c", u.Field(1, record("c"))),
+			u.Entry("a", u.Field(1, record("a"))),
+			u.Entry("b", u.Field(1, record("b"))),
+		)
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if strings.Join(validated, ",") != "c,a,b" {
+			t.Errorf("expected fields to be validated in declaration order, got %v", validated)
+		}
+	})
+
+	t.Run("can be nested in a Schema", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{
+			"user": u.Ordered(
+				u.Entry("name", &mockValidable{hasErrors: true, errorMessage: "name error"}),
+			),
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"user":{"name":{"errors":["name error"]}}}`)
+	})
+}
+
+func TestSchema_Validate_Order(t *testing.T) {
+	t.Run("reports errors sorted by tag", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{
+			"zeta":  &mockValidable{hasErrors: true, errorMessage: "zeta error"},
+			"alpha": &mockValidable{hasErrors: true, errorMessage: "alpha error"},
+			"mu": u.Schema{
+				"b": &mockValidable{hasErrors: true, errorMessage: "b error"},
+				"a": &mockValidable{hasErrors: true, errorMessage: "a error"},
+			},
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"alpha":{"errors":["alpha error"]},`+
+			`"mu":{"a":{"errors":["a error"]},"b":{"errors":["b error"]}},"zeta":{"errors":["zeta error"]}}`)
+	})
+}
+
+func TestWithNested(t *testing.T) {
+	t.Run("reports field and nested errors under the same tag", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{
+			"items": u.WithNested(
+				&mockValidable{hasErrors: true, errorMessage: "too few items"},
+				u.Ordered(u.Entry("0", u.Schema{
+					"qty": &mockValidable{hasErrors: true, errorMessage: "qty error"},
+				})),
+			),
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"items":{"errors":["too few items"],"0":{"qty":{"errors":["qty error"]}}}}`)
+	})
+
+	t.Run("skips the nested object when the run stops", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{
+			"items": u.WithNested(
+				&mockValidable{hasErrors: true, errorMessage: "too few items"},
+				u.Schema{"qty": &mockValidable{hasErrors: true, errorMessage: "qty error"}},
+			),
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate(u.WithFailFast())
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"items":{"errors":["too few items"]}}`)
+	})
+}
+
 func TestValidator_NewSouuup(t *testing.T) {
 	t.Run("returns a pointer to Souuup", func(t *testing.T) {
 		// Act
@@ -536,7 +795,7 @@ func (m *mockValidable) Validate(ve *u.ValidationError, tag u.FieldTag) {
 		if m.errorMessage != "" {
 			errorMsg = m.errorMessage
 		}
-		ve.AddError(tag, u.RuleError(errorMsg))
+		ve.AddError(tag, errors.New(errorMsg))
 	}
 }
 
@@ -547,7 +806,7 @@ func (m *mockValidable) Errors() *u.ValidationError {
 		if m.errorMessage != "" {
 			errorMsg = m.errorMessage
 		}
-		ve.AddError("mock", u.RuleError(errorMsg))
+		ve.AddError("mock", errors.New(errorMsg))
 	}
 	return ve
 }
@@ -631,3 +890,91 @@ func newComplexTestCase() complexTestCase {
 		},
 	}
 }
+
+func TestEach(t *testing.T) {
+	type item struct {
+		ProductID string
+		Quantity  int
+	}
+	positive := func(fs u.FieldState[int]) error {
+		if fs.Value < 1 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	itemSchema := func(i item) u.Schema {
+		return u.Schema{
+			"productID": u.Field(i.ProductID),
+			"quantity":  u.Field(i.Quantity, positive),
+		}
+	}
+	items := make([]item, 11)
+	for i := range items {
+		items[i] = item{ProductID: "P", Quantity: 1}
+	}
+	items[2].Quantity, items[10].Quantity = 0, -1
+
+	t.Run("reports element failures keyed by index", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{"items": u.Each(items, itemSchema)}
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		var ve *u.ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected a ValidationError, got %v", err)
+		}
+		assertErrorMessage(t, err.Error(),
+			`{"items":{"2":{"quantity":{"errors":["must be positive"]}},"10":{"quantity":{"errors":["must be positive"]}}}}`)
+
+		if _, ok := ve.ToMap()["items"]["10"].(map[string]any)["quantity"]; !ok {
+			t.Errorf("expected the failure of element 10 in ToMap, got %v", ve.ToMap())
+		}
+
+		flat := ve.Flatten(u.BracketPath)
+		if len(flat) != 2 || flat[0].Path != "items[2].quantity" || flat[1].Path != "items[10].quantity" {
+			t.Errorf("expected indexed paths, got %v", flat)
+		}
+	})
+
+	t.Run("validates elements with fields", func(t *testing.T) {
+		// Arrange
+		schema := u.Schema{
+			"quantities": u.Each([]int{1, 0}, func(q int) *u.FieldDef[int] { return u.Field(q, positive) }),
+		}
+
+		// Act
+		err := u.NewSouuup(schema).Validate()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error, got nil")
+		}
+		assertErrorMessage(t, err.Error(), `{"quantities":{"1":{"errors":["must be positive"]}}}`)
+	})
+}
+
+func BenchmarkEach(b *testing.B) {
+	positive := func(fs u.FieldState[int]) error {
+		if fs.Value < 1 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	quantities := make([]int, 10_000)
+	for i := range quantities {
+		quantities[i] = i % 2
+	}
+
+	for b.Loop() {
+		schema := u.Schema{
+			"items": u.Each(quantities, func(q int) u.Schema {
+				return u.Schema{"quantity": u.Field(q, positive)}
+			}),
+		}
+		err := u.NewSouuup(schema).Validate()
+		_ = err.Error()
+	}
+}